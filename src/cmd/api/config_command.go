@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+)
+
+// runConfigCommand runs `config validate`, which loads the config exactly as the server would and reports every
+// problem found. It returns the process exit code so deploy pipelines can gate on it.
+func runConfigCommand(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "validate" {
+		fmt.Fprintln(stderr, "usage: config validate [-config path] [-set name=value ...]")
+		return 2
+	}
+
+	if _, err := config.Load(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+
+		var problems interface{ Unwrap() []error }
+		if !errors.As(err, &problems) {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+
+		fmt.Fprintln(stderr, "invalid config:")
+		for _, problem := range problems.Unwrap() {
+			fmt.Fprintf(stderr, "  - %v\n", problem)
+		}
+		return 1
+	}
+
+	fmt.Fprintln(stdout, "config is valid")
+	return 0
+}
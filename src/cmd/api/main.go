@@ -1,34 +1,75 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers"
 	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
 	userClient "github.com/RobsonDevCode/go-profile-service/src/internal/clients/user"
-	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	configuration "github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
 	"github.com/RobsonDevCode/go-profile-service/src/internal/repository/mysql"
 	"github.com/RobsonDevCode/go-profile-service/src/internal/services"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func main() {
-	config, err := config.Load()
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	config, err := configuration.Load(os.Args[1:])
 	if err != nil {
 		log.Fatalf("config error: %v", err)
 		return
 	}
 
 	database := mysql.NewUserDataBase(*config)
-	logger, err := zap.NewProduction()
+	logLevel := zap.NewAtomicLevel()
+	logger, err := newLogger(logLevel, config.Log.Level)
 	if err != nil {
 		log.Fatal(err)
 		return
 	}
+	logger.Info("config loaded", zap.Stringer("config", config))
+
+	settings := configuration.NewStore(config)
+	reloader := configuration.NewReloader(settings, os.Args[1:], logger)
+	reloader.OnReload(func(config *configuration.Config) {
+		if err := logLevel.UnmarshalText([]byte(config.Log.Level)); err != nil {
+			logger.Sugar().Warnf("keeping log level %s, %v", logLevel.Level(), err)
+		}
+	})
+
+	cache, stopCache, err := newCache(config.Cache, caching.Options{
+		MaxEntries:      config.Cache.MaxEntries,
+		MaxBytes:        config.Cache.MaxBytes,
+		NegativeTTL:     config.Cache.NegativeTTL,
+		CacheableErrors: []error{domain.ErrProfileNotFound, userClient.ErrUserNotFound},
+		StaleTTL:        config.Cache.StaleTTL,
+		Jitter:          config.Cache.Jitter,
+	}, logger)
+	if err != nil {
+		logger.Sugar().Fatalf("start up error, %v", err)
+		return
+	}
+	defer stopCache()
 
-	cache := &caching.Cache{}
-	userClient, err := userClient.NewUserClient(*config, cache)
+	userClient, err := userClient.NewUserClient(settings, cache, logger)
 	if err != nil {
 		logger.Sugar().Panicf("start up error, %w", err)
 		return
@@ -37,31 +78,200 @@ func main() {
 	profileRetrievalRepo := mysql.NewProfileRetrievalRepository(database)
 	profileWriterRepo := mysql.NewWriterRetrievalRepository(database, logger)
 	followRetrievalRepo := mysql.NewFollowerRetrivalRepository(database, logger)
+	followWriterRepo := mysql.NewFollowerWriterRepository(database, logger)
+	followRequestRepo := mysql.NewFollowRequestRepository(database, logger)
+	blockRepo := mysql.NewBlockRepository(database, logger)
+	muteRepo := mysql.NewMuteRepository(database, logger)
 
-	profileRetrievalService := services.NewProfileRetrievalService(profileRetrievalRepo, cache)
+	cursorSigner := domain.NewCursorSigner(config.Pagination.CursorKey.Value())
+
+	profileRetrievalService := services.NewProfileRetrievalService(profileRetrievalRepo, blockRepo, cache, settings)
 	profileWriterService := services.NewProfileWriterService(profileWriterRepo, *profileRetrievalService, userClient, *logger)
-	followRetrievalService := services.NewFollowerRetrivalService(followRetrievalRepo, *profileRetrievalService, *logger)
+	followRetrievalService := services.NewFollowerRetrivalService(followRetrievalRepo, *profileRetrievalService, cache, cursorSigner, *logger)
+	followWriterService := services.NewFollowerWriterService(followWriterRepo, followRequestRepo, *profileRetrievalService, userClient, *logger)
+	followRequestService := services.NewFollowRequestService(followRequestRepo, cache, *logger)
+	blockService := services.NewBlockService(blockRepo, *profileRetrievalService, *logger)
+	muteService := services.NewMuteService(muteRepo, *profileRetrievalService, *logger)
+
+	profileHandler := handlers.NewProfileHandler(profileRetrievalService, profileWriterService, userClient, settings, logger)
+	followerHandler := handlers.NewFollowerHandler(profileRetrievalService, followRetrievalService, followWriterService, settings, logger)
+	followRequestHandler := handlers.NewFollowRequestHandler(followRequestService, settings, logger)
+	blockHandler := handlers.NewBlockHandler(blockService, settings, logger)
+	muteHandler := handlers.NewMuteHandler(muteService, settings, logger)
+	cacheAdminHandler := handlers.NewCacheAdminHandler(cache, logger)
 
-	profileHandler := handlers.NewProfileHandler(profileRetrievalService, profileWriterService, userClient, logger)
-	followerHandler := handlers.NewFollowerHandler(profileRetrievalService, followRetrievalService, logger)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		caching.NewCollector(cache),
+	)
+	metrics := http.NewServeMux()
+	metrics.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
 
-	router := Setup(profileHandler, followerHandler, config, logger)
+	healthHandler := handlers.NewHealthHandler()
 
-	if err := router.Run(":8080"); err != nil {
-		logger.Sugar().Errorf("Failed to start server: %v", err)
+	router := Setup(profileHandler, followerHandler, followRequestHandler, blockHandler, muteHandler, cacheAdminHandler,
+		healthHandler, config, logger)
+
+	server := &http.Server{
+		Addr:    config.Server.Addr,
+		Handler: router,
 	}
 
+	// metrics are served on their own listener so they can be kept off the public network
+	metricsServer := &http.Server{
+		Addr:    config.Server.MetricsAddr,
+		Handler: metrics,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		if err := reloader.Watch(ctx); err != nil {
+			logger.Sugar().Warnf("config reload is off, %v", err)
+		}
+	}()
+
+	for _, httpServer := range []*http.Server{server, metricsServer} {
+		go func() {
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Sugar().Errorf("Failed to start server on %s: %v", httpServer.Addr, err)
+				stop()
+			}
+		}()
+	}
+
+	warmCache(ctx, config.Cache.Warmup, profileRetrievalService, logger)
+	healthHandler.SetReady()
+
+	<-ctx.Done()
+	logger.Info("shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Sugar().Errorf("Failed to shut down server cleanly: %v", err)
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		logger.Sugar().Errorf("Failed to shut down metrics server cleanly: %v", err)
+	}
 }
 
 func Setup(profileHandler *handlers.ProfileHandler,
 	followerHandler *handlers.FollowerHandler,
-	config *config.Config, logger *zap.Logger) *gin.Engine {
+	followRequestHandler *handlers.FollowRequestHandler,
+	blockHandler *handlers.BlockHandler,
+	muteHandler *handlers.MuteHandler,
+	cacheAdminHandler *handlers.CacheAdminHandler,
+	healthHandler *handlers.HealthHandler,
+	config *configuration.Config, logger *zap.Logger) *gin.Engine {
 	router := gin.Default()
+	healthHandler.Register(router)
+
 	api := router.Group("profile/v1")
 	{
 		profileHandler.Register(api, config, logger)
 		followerHandler.Register(api, config, logger)
+		followRequestHandler.Register(api, config, logger)
+		blockHandler.Register(api, config, logger)
+		muteHandler.Register(api, config, logger)
+		cacheAdminHandler.Register(api, config, logger)
 	}
 
 	return router
 }
+
+// newLogger builds the production logger at level, which can be changed later through the atomic level.
+func newLogger(atomicLevel zap.AtomicLevel, level string) (*zap.Logger, error) {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	atomicLevel.SetLevel(parsed)
+
+	zapConfig := zap.NewProductionConfig()
+	zapConfig.Level = atomicLevel
+	return zapConfig.Build()
+}
+
+// warmCache loads the most followed profiles into the cache within the warmup budget, a failed or
+// cut short warmup is logged and start up carries on with whatever was loaded.
+func warmCache(ctx context.Context, settings configuration.WarmupSettings, profileService *services.ProfileRetrievalService, logger *zap.Logger) {
+	if !settings.Enabled {
+		return
+	}
+
+	warmCtx, cancel := context.WithTimeout(ctx, settings.Budget)
+	defer cancel()
+
+	started := time.Now()
+	warmed, err := profileService.Warm(settings.Count, warmCtx)
+	if err != nil {
+		logger.Sugar().Warnf("cache warmup stopped after %s, %v", time.Since(started), err)
+		return
+	}
+
+	logger.Sugar().Infof("cache warmed with %d profiles in %s", warmed, time.Since(started))
+}
+
+// newCache builds the cache backend named in settings, the returned func stops it and closes its connections.
+func newCache(settings configuration.CacheSettings, options caching.Options, logger *zap.Logger) (caching.Cache, func(), error) {
+	newMemoryCache := func() *caching.MemoryCache {
+		cache := caching.NewMemoryCache(options)
+		cache.Start(settings.CleanupInterval)
+		return cache
+	}
+
+	switch settings.Backend {
+	case "", "memory":
+		cache := newMemoryCache()
+		return cache, cache.Stop, nil
+	case "redis", "near":
+	default:
+		return nil, nil, fmt.Errorf("unknown cache backend %q", settings.Backend)
+	}
+
+	redisOptions := caching.RedisOptions{
+		KeyPrefix: settings.Redis.KeyPrefix,
+		OnError: func(err error) {
+			logger.Sugar().Warnf("redis cache error, %v", err)
+		},
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     settings.Redis.Addr,
+		Password: settings.Redis.Password.Value(),
+		DB:       settings.Redis.DB,
+	})
+
+	versionCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := caching.CheckRedisVersion(client, versionCtx); err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	remote := caching.NewRedisCache(client, options, redisOptions)
+
+	if settings.Backend == "redis" {
+		return remote, func() {
+			remote.Stop()
+			client.Close()
+		}, nil
+	}
+
+	local := newMemoryCache()
+	near, err := caching.NewNearCache(local, remote, client, settings.Redis.Channel)
+	if err != nil {
+		local.Stop()
+		client.Close()
+		return nil, nil, err
+	}
+
+	return near, func() {
+		near.Stop()
+		client.Close()
+	}, nil
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/services"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type BlockHandler struct {
+	blockService *services.BlockService
+	settings     *config.Store
+	logger       *zap.Logger
+}
+
+func NewBlockHandler(blockService *services.BlockService, settings *config.Store, logger *zap.Logger) *BlockHandler {
+	return &BlockHandler{
+		blockService: blockService,
+		settings:     settings,
+		logger:       logger,
+	}
+}
+
+func (h *BlockHandler) Register(router *gin.RouterGroup,
+	config *config.Config, logger *zap.Logger) {
+	blockGroup := router.Group("profile")
+	blockGroup.Use(validator.JWTAuthMiddleWare(config, logger))
+	{
+		blockGroup.POST(":id/block", h.Block)
+		blockGroup.DELETE(":id/block", h.Unblock)
+	}
+}
+
+func (h *BlockHandler) Block(c *gin.Context) {
+	blockedId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	blockerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := h.blockService.Block(blockerId, blockedId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "profile blocked!"})
+
+	h.logger.Sugar().Infof("%s blocked %s", blockerId, blockedId)
+}
+
+func (h *BlockHandler) Unblock(c *gin.Context) {
+	blockedId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	blockerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := h.blockService.Unblock(blockerId, blockedId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+
+	h.logger.Sugar().Infof("%s unblocked %s", blockerId, blockedId)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	adminRole        = "admin"
+	defaultKeysLimit = 100
+	maxKeysLimit     = 1000
+)
+
+// CacheAdminHandler lets operators look inside the cache, every route needs a jwt carrying the admin role.
+type CacheAdminHandler struct {
+	cache  caching.Cache
+	logger *zap.Logger
+}
+
+func NewCacheAdminHandler(cache caching.Cache, logger *zap.Logger) *CacheAdminHandler {
+	return &CacheAdminHandler{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+func (h *CacheAdminHandler) Register(router *gin.RouterGroup,
+	config *config.Config, logger *zap.Logger) {
+	admin := router.Group("admin/cache")
+	admin.Use(validator.JWTAuthMiddleWare(config, logger), validator.RequireRole(adminRole, logger))
+	{
+		admin.GET("stats", h.GetStats)
+		admin.GET("keys", h.GetKeys)
+		admin.GET("entry", h.GetEntry)
+		admin.DELETE("namespaces/:namespace", h.FlushNamespace)
+	}
+}
+
+func (h *CacheAdminHandler) GetStats(c *gin.Context) {
+	stats := h.cache.Stats()
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats":     stats,
+		"hit_ratio": stats.HitRatio(),
+	})
+}
+
+// GetKeys lists the keys starting with the prefix query param, up to limit.
+func (h *CacheAdminHandler) GetKeys(c *gin.Context) {
+	limit := defaultKeysLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxKeysLimit {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit must be between 1 and " + strconv.Itoa(maxKeysLimit),
+			})
+			return
+		}
+		limit = parsed
+	}
+
+	keys, err := h.cache.Keys(c.Query("prefix"), limit)
+	if err != nil {
+		h.logger.Sugar().Errorf("error listing cache keys, %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong please try again later!"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"keys": keys,
+	})
+}
+
+// GetEntry describes the entry held under the key query param, including when it expires.
+func (h *CacheAdminHandler) GetEntry(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "key is required"})
+		return
+	}
+
+	entry, ok, err := h.cache.Inspect(key)
+	if err != nil {
+		h.logger.Sugar().Errorf("error inspecting cache key %s, %v", key, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong please try again later!"})
+		return
+	}
+
+	if !ok {
+		writeProblem(c, http.StatusNotFound, "Not Found", "no cache entry for "+key)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entry": entry,
+	})
+}
+
+// FlushNamespace removes every entry in a namespace such as profile, exists, relationship or user.
+func (h *CacheAdminHandler) FlushNamespace(c *gin.Context) {
+	namespace := c.Param("namespace")
+
+	removed := h.cache.DeletePrefix(namespace + "-")
+	h.logger.Sugar().Infof("flushed %d entries from cache namespace %s", removed, namespace)
+
+	c.JSON(http.StatusOK, gin.H{
+		"removed": removed,
+	})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FollowRequestHandler struct {
+	followRequestService *services.FollowRequestService
+	settings             *config.Store
+	logger               *zap.Logger
+}
+
+func NewFollowRequestHandler(followRequestService *services.FollowRequestService,
+	settings *config.Store,
+	logger *zap.Logger) *FollowRequestHandler {
+	return &FollowRequestHandler{
+		followRequestService: followRequestService,
+		settings:             settings,
+		logger:               logger,
+	}
+}
+
+func (h *FollowRequestHandler) Register(router *gin.RouterGroup,
+	config *config.Config, logger *zap.Logger) {
+	requests := router.Group("follow-requests")
+	requests.Use(validator.JWTAuthMiddleWare(config, logger))
+	{
+		requests.GET("incoming", h.GetIncoming)
+		requests.GET("outgoing", h.GetOutgoing)
+		requests.POST(":id/approve", h.Approve)
+		requests.POST(":id/reject", h.Reject)
+		requests.DELETE(":id", h.Cancel)
+	}
+}
+
+func (h *FollowRequestHandler) GetIncoming(c *gin.Context) {
+	h.getPage(c, h.followRequestService.GetIncoming)
+}
+
+func (h *FollowRequestHandler) GetOutgoing(c *gin.Context) {
+	h.getPage(c, h.followRequestService.GetOutgoing)
+}
+
+func (h *FollowRequestHandler) getPage(c *gin.Context,
+	get func(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.FollowRequest], error)) {
+	id, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	paginationOptions := domain.GetOptions(c, pageLimits(h.settings))
+	if c.IsAborted() {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.List)
+	defer cancel()
+
+	pagedResult, err := get(id, paginationOptions, ctx)
+	if err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": pagedResult,
+	})
+}
+
+// Approve accepts the follow request the :id profile sent to the caller.
+func (h *FollowRequestHandler) Approve(c *gin.Context) {
+	h.respond(c, h.followRequestService.Approve, http.StatusOK, "follow request approved!")
+}
+
+// Reject declines the follow request the :id profile sent to the caller.
+func (h *FollowRequestHandler) Reject(c *gin.Context) {
+	h.respond(c, h.followRequestService.Reject, http.StatusOK, "follow request rejected!")
+}
+
+// Cancel withdraws the follow request the caller sent to the :id profile.
+func (h *FollowRequestHandler) Cancel(c *gin.Context) {
+	requesterId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	id, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := h.followRequestService.Cancel(id, requesterId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FollowRequestHandler) respond(c *gin.Context,
+	action func(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error,
+	status int, message string) {
+	id, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	requesterId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := action(id, requesterId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(status, gin.H{"message": message})
+}
@@ -3,74 +3,218 @@ package handlers
 import (
 	"context"
 	"net/http"
-	"time"
 
 	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
-	"github.com/google/uuid"
 
 	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
 	"github.com/RobsonDevCode/go-profile-service/src/internal/services"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type FollowerHandler struct {
 	profileRetrievalService *services.ProfileRetrievalService
 	followRetrievalService  *services.FollowerRetrievalService
+	followWriterService     *services.FollowerWriterService
+	settings                *config.Store
 	logger                  *zap.Logger
 }
 
 func NewFollowerHandler(profileRetrievalService *services.ProfileRetrievalService, followRetrievalService *services.FollowerRetrievalService,
+	followWriterService *services.FollowerWriterService,
+	settings *config.Store,
 	logger *zap.Logger,
 ) *FollowerHandler {
 	return &FollowerHandler{
 		profileRetrievalService: profileRetrievalService,
 		followRetrievalService:  followRetrievalService,
+		followWriterService:     followWriterService,
+		settings:                settings,
 		logger:                  logger,
 	}
 }
 
 func (h *FollowerHandler) Register(router *gin.RouterGroup,
 	config *config.Config, logger *zap.Logger) {
-	followerGroup := router.Group("")
+	followerGroup := router.Group("profile")
 	followerGroup.Use(validator.JWTAuthMiddleWare(config, logger))
 	{
-		followerGroup.GET(":id")
+		followerGroup.GET(":id/followers", h.GetPaged)
+		followerGroup.GET(":id/following", h.GetFollowingPaged)
+		followerGroup.GET(":id/relationship", h.GetRelationship)
+		followerGroup.POST(":id/follow", h.Follow)
+		followerGroup.DELETE(":id/follow", h.Unfollow)
 	}
 }
 
+// GetPaged lists followers, using ?cursor=&limit= keyset pagination when given and ?page=&size= otherwise.
 func (h *FollowerHandler) GetPaged(c *gin.Context) {
-	id := c.Param("id")
-	if id == "" {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing profile id param"})
+	if domain.UsesCursor(c) {
+		h.getCursorPaged(c, h.followRetrievalService.GetCursorPage)
+		return
+	}
+
+	h.getPaged(c, h.followRetrievalService.GetPage)
+}
+
+func (h *FollowerHandler) GetFollowingPaged(c *gin.Context) {
+	if domain.UsesCursor(c) {
+		h.getCursorPaged(c, h.followRetrievalService.GetFollowingCursorPage)
+		return
+	}
+
+	h.getPaged(c, h.followRetrievalService.GetFollowingPage)
+}
+
+func (h *FollowerHandler) getCursorPaged(c *gin.Context,
+	get func(id uuid.UUID, callerId uuid.UUID, cursorOptions domain.CursorOptions, ctx context.Context) (domain.CursorPagedResult[[]domain.User], error)) {
+	id, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	viewerId, ok := callerId(c, h.logger)
+	if !ok {
 		return
 	}
-	uuid, err := uuid.Parse(id)
+
+	cursorOptions := domain.GetCursorOptions(c, pageLimits(h.settings))
+	if c.IsAborted() {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.List)
+	defer cancel()
+
+	pagedResult, err := get(id, viewerId, cursorOptions, ctx)
 	if err != nil {
-		h.logger.Sugar().Errorf("unable to parse id to uuid, %w", err)
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"validatoin error": "Invalid id sent",
-		})
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": pagedResult,
+	})
+}
+
+func (h *FollowerHandler) getPaged(c *gin.Context,
+	get func(id uuid.UUID, callerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.User], error)) {
+	id, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	viewerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
 	}
 
-	paginationOptions := domain.GetOptions(c)
+	paginationOptions := domain.GetOptions(c, pageLimits(h.settings))
+	if c.IsAborted() {
+		return
+	}
 
 	ctx := c.Request.Context()
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.List)
 	defer cancel()
 
-	pagedResult, err := h.followRetrievalService.GetPage(uuid, paginationOptions, ctx)
+	pagedResult, err := get(id, viewerId, paginationOptions, ctx)
 	if err != nil {
-		h.logger.Sugar().Errorf("error getting page: %w", err)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-			"error": "something went wrong please try again later!",
-		})
+		h.logger.Sugar().Errorf("error getting page: %v", err)
+		writeError(c, ctx, h.logger, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"result": pagedResult,
 	})
 
-	h.logger.Sugar().Infof("Get Page for %s succesfully completed", uuid)
+	h.logger.Sugar().Infof("Get Page for %s succesfully completed", id)
+}
+
+func (h *FollowerHandler) Follow(c *gin.Context) {
+	id, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	followerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	pending, err := h.followWriterService.Follow(id, followerId, ctx)
+	if err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	if pending {
+		c.JSON(http.StatusAccepted, gin.H{"message": "follow request sent!"})
+		h.logger.Sugar().Infof("%s requested to follow %s", followerId, id)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "profile followed!"})
+
+	h.logger.Sugar().Infof("%s followed %s", followerId, id)
+}
+
+func (h *FollowerHandler) Unfollow(c *gin.Context) {
+	id, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	followerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := h.followWriterService.Unfollow(id, followerId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+
+	h.logger.Sugar().Infof("%s unfollowed %s", followerId, id)
+}
+
+// GetRelationship describes how the caller and the :id profile are connected.
+func (h *FollowerHandler) GetRelationship(c *gin.Context) {
+	targetId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	viewerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	relationship, err := h.followRetrievalService.GetRelationship(viewerId, targetId, ctx)
+	if err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"relationship": relationship,
+	})
 }
@@ -1,7 +1,124 @@
 package handlers
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
 
 type Handler interface {
 	Register(router *gin.RouterGroup)
 }
+
+// pageLimits reads the pagination limits from the current config, so a reload applies to the next request.
+func pageLimits(settings *config.Store) domain.PageLimits {
+	pagination := settings.Get().Pagination
+	return domain.PageLimits{
+		DefaultSize: pagination.DefaultSize,
+		MaxSize:     pagination.MaxSize,
+	}
+}
+
+// writeError maps an error returned by a service onto a response, anything unexpected is logged and returned as a 500.
+func writeError(c *gin.Context, ctx context.Context, logger *zap.Logger, err error) {
+	if ctx.Err() == context.DeadlineExceeded {
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request Time Out"})
+		return
+	}
+	if ctx.Err() == context.Canceled {
+		c.AbortWithStatus(499)
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrProfileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile not found"})
+	case errors.Is(err, domain.ErrNotFollowing):
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile is not being followed"})
+	case errors.Is(err, domain.ErrAlreadyFollowing):
+		c.JSON(http.StatusConflict, gin.H{"error": "profile is already being followed"})
+	case errors.Is(err, domain.ErrCannotFollowSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a profile can't follow itself"})
+	case errors.Is(err, domain.ErrFollowRequestNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "follow request not found"})
+	case errors.Is(err, domain.ErrFollowRequestExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "follow request already sent"})
+	case errors.Is(err, domain.ErrCannotBlockSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a profile can't block itself"})
+	case errors.Is(err, domain.ErrAlreadyBlocked):
+		c.JSON(http.StatusConflict, gin.H{"error": "profile is already blocked"})
+	case errors.Is(err, domain.ErrNotBlocked):
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile is not blocked"})
+	case errors.Is(err, domain.ErrCannotMuteSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a profile can't mute itself"})
+	case errors.Is(err, domain.ErrAlreadyMuted):
+		c.JSON(http.StatusConflict, gin.H{"error": "profile is already muted"})
+	case errors.Is(err, domain.ErrNotMuted):
+		c.JSON(http.StatusNotFound, gin.H{"error": "profile is not muted"})
+	case errors.Is(err, domain.ErrBlocked):
+		writeProblem(c, http.StatusForbidden, "Forbidden", "this profile can't be followed")
+	case errors.Is(err, domain.ErrInvalidProfile):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	case errors.Is(err, domain.ErrForbidden):
+		writeProblem(c, http.StatusForbidden, "Forbidden", "this profile is private, follow it to see its followers")
+	default:
+		logger.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong please try again later!"})
+	}
+}
+
+// ProblemDetails is the RFC 7807 body of an error response from this api.
+type ProblemDetails struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func writeProblem(c *gin.Context, status int, title string, detail string) {
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(status, ProblemDetails{
+		Title:  title,
+		Detail: detail,
+		Status: status,
+	})
+}
+
+// parseIdParam reads the :id path param as a uuid, aborting with a 400 when it's missing or invalid.
+func parseIdParam(c *gin.Context) (uuid.UUID, bool) {
+	id := c.Param("id")
+	if id == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing profile id param"})
+		return uuid.Nil, false
+	}
+
+	profileId, err := uuid.Parse(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"validation error": "Invalid id sent",
+		})
+		return uuid.Nil, false
+	}
+
+	return profileId, true
+}
+
+// callerId reads the authenticated user from the jwt, aborting with a 401 when it isn't present.
+func callerId(c *gin.Context, logger *zap.Logger) (uuid.UUID, bool) {
+	id, err := validator.GetCallerId(c)
+	if err != nil {
+		logger.Sugar().Errorf("unable to get caller id, %v", err)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User unauthorized"})
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler answers the liveness and readiness probes, readiness stays down until SetReady is called.
+type HealthHandler struct {
+	ready atomic.Bool
+}
+
+func NewHealthHandler() *HealthHandler {
+	return &HealthHandler{}
+}
+
+func (h *HealthHandler) Register(router gin.IRouter) {
+	health := router.Group("health")
+	{
+		health.GET("live", h.Live)
+		health.GET("ready", h.Ready)
+	}
+}
+
+// SetReady flips the readiness probe once start up work such as cache warmup has finished.
+func (h *HealthHandler) SetReady() {
+	h.ready.Store(true)
+}
+
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if !h.ready.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "starting"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ready"})
+}
@@ -8,9 +8,15 @@ import (
 	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+const (
+	callerIdKey = "callerId"
+	claimsKey   = "claims"
+)
+
 func JWTAuthMiddleWare(config *config.Config, logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -38,7 +44,7 @@ func JWTAuthMiddleWare(config *config.Config, logger *zap.Logger) gin.HandlerFun
 			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 			}
-			return []byte(config.JWTSettings.Key), nil
+			return []byte(config.JWTSettings.Key.Value()), nil
 		})
 
 		if err != nil {
@@ -57,5 +63,54 @@ func JWTAuthMiddleWare(config *config.Config, logger *zap.Logger) gin.HandlerFun
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User unauthorized"})
 			return
 		}
+
+		if subject, err := token.Claims.GetSubject(); err == nil && subject != "" {
+			c.Set(callerIdKey, subject)
+		}
+		c.Set(claimsKey, token.Claims)
+	}
+}
+
+// RequireRole only lets through callers whose jwt carries role in its "role" or "roles" claim,
+// it must run after JWTAuthMiddleWare.
+func RequireRole(role string, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, _ := c.Get(claimsKey)
+		mapClaims, ok := claims.(jwt.MapClaims)
+		if !ok || !hasRole(mapClaims, role) {
+			logger.Sugar().Warnf("caller without the %s role denied access to %s", role, c.FullPath())
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "User does not have access "})
+			return
+		}
 	}
 }
+
+func hasRole(claims jwt.MapClaims, role string) bool {
+	if single, ok := claims["role"].(string); ok && single == role {
+		return true
+	}
+
+	roles, _ := claims["roles"].([]interface{})
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetCallerId returns the id of the authenticated user taken from the jwt sub claim.
+func GetCallerId(c *gin.Context) (uuid.UUID, error) {
+	subject := c.GetString(callerIdKey)
+	if subject == "" {
+		return uuid.Nil, fmt.Errorf("jwt is missing the sub claim")
+	}
+
+	callerId, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("jwt sub claim is not a valid id: %w", err)
+	}
+
+	return callerId, nil
+}
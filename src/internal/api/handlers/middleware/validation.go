@@ -9,3 +9,13 @@ func ValidateProfile(profile domain.Profile) error {
 	validate := validator.New()
 	return validate.Struct(profile)
 }
+
+func ValidateMuteCheck(request domain.MuteCheckRequest) error {
+	validate := validator.New()
+	return validate.Struct(request)
+}
+
+func ValidateServiceMuteCheck(request domain.ServiceMuteCheckRequest) error {
+	validate := validator.New()
+	return validate.Struct(request)
+}
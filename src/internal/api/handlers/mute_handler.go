@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// serviceRole is carried by the jwts other services call in with.
+const serviceRole = "service"
+
+type MuteHandler struct {
+	muteService *services.MuteService
+	settings    *config.Store
+	logger      *zap.Logger
+}
+
+func NewMuteHandler(muteService *services.MuteService, settings *config.Store, logger *zap.Logger) *MuteHandler {
+	return &MuteHandler{
+		muteService: muteService,
+		settings:    settings,
+		logger:      logger,
+	}
+}
+
+func (h *MuteHandler) Register(router *gin.RouterGroup,
+	config *config.Config, logger *zap.Logger) {
+	profile := router.Group("profile")
+	profile.Use(validator.JWTAuthMiddleWare(config, logger))
+	{
+		profile.POST(":id/mute", h.Mute)
+		profile.DELETE(":id/mute", h.Unmute)
+	}
+
+	mutes := router.Group("mutes")
+	mutes.Use(validator.JWTAuthMiddleWare(config, logger))
+	{
+		mutes.GET("", h.GetPaged)
+		mutes.POST("check", h.Check)
+	}
+
+	internal := router.Group("internal/mutes")
+	internal.Use(validator.JWTAuthMiddleWare(config, logger), validator.RequireRole(serviceRole, logger))
+	{
+		internal.POST("check", h.ServiceCheck)
+	}
+}
+
+func (h *MuteHandler) Mute(c *gin.Context) {
+	mutedId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	muterId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := h.muteService.Mute(muterId, mutedId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "profile muted!"})
+}
+
+func (h *MuteHandler) Unmute(c *gin.Context) {
+	mutedId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	muterId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	if err := h.muteService.Unmute(muterId, mutedId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPaged lists the profiles the caller has muted.
+func (h *MuteHandler) GetPaged(c *gin.Context) {
+	muterId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	paginationOptions := domain.GetOptions(c, pageLimits(h.settings))
+	if c.IsAborted() {
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.List)
+	defer cancel()
+
+	pagedResult, err := h.muteService.GetPage(muterId, paginationOptions, ctx)
+	if err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": pagedResult,
+	})
+}
+
+// Check answers, for a batch of ids, whether the caller has muted each of them.
+func (h *MuteHandler) Check(c *gin.Context) {
+	muterId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	var request domain.MuteCheckRequest
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := validator.ValidateMuteCheck(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	h.check(c, muterId, request.Ids)
+}
+
+// ServiceCheck answers the same question for any user, it is only open to other services.
+func (h *MuteHandler) ServiceCheck(c *gin.Context) {
+	var request domain.ServiceMuteCheckRequest
+	if err := c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := validator.ValidateServiceMuteCheck(request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	h.check(c, request.UserId, request.Ids)
+}
+
+func (h *MuteHandler) check(c *gin.Context, muterId uuid.UUID, ids []uuid.UUID) {
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	muted, err := h.muteService.AreMuted(muterId, ids, ctx)
+	if err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"muted": muted,
+	})
+}
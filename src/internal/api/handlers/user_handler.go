@@ -2,8 +2,8 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
-	"time"
 
 	validator "github.com/RobsonDevCode/go-profile-service/src/internal/api/handlers/middleware"
 	client "github.com/RobsonDevCode/go-profile-service/src/internal/clients/user"
@@ -20,17 +20,20 @@ type ProfileHandler struct {
 	readerService *services.ProfileRetrievalService
 	writerService *services.ProfileWriterService
 	userClient    *client.UserClient
+	settings      *config.Store
 	logger        *zap.Logger
 }
 
 func NewProfileHandler(readerService *services.ProfileRetrievalService,
 	writerService *services.ProfileWriterService,
 	userClient *client.UserClient,
+	settings *config.Store,
 	logger *zap.Logger) *ProfileHandler {
 	return &ProfileHandler{
 		readerService: readerService,
 		writerService: writerService,
 		userClient:    userClient,
+		settings:      settings,
 		logger:        logger,
 	}
 }
@@ -43,6 +46,8 @@ func (h *ProfileHandler) Register(router *gin.RouterGroup,
 	{
 		profile.GET(":id", h.GetProfile)
 		profile.POST("", h.CreateProfile)
+		profile.PATCH(":id", h.UpdateProfile)
+		profile.DELETE(":id", h.DeleteProfile)
 	}
 }
 
@@ -63,26 +68,22 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadGateway, gin.H{
 			"validation error": "Invalid id sent",
 		})
+		return
+	}
+
+	viewerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
 	}
 
 	ctx := c.Request.Context()
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.List)
 	defer cancel()
 
-	profile, err := h.readerService.GetById(profileId, ctx)
+	profile, err := h.readerService.GetVisibleById(profileId, viewerId, ctx)
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request Time Out"})
-			return
-		}
-		if ctx.Err() == context.Canceled {
-			c.AbortWithStatus(499)
-			return
-		} else {
-			h.logger.Error(err.Error())
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong please try again later!"})
-			return
-		}
+		writeError(c, ctx, h.logger, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -111,7 +112,7 @@ func (h *ProfileHandler) CreateProfile(c *gin.Context) {
 	h.logger.Info("Attempting to create profile")
 
 	ctx := c.Request.Context()
-	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
 	defer cancel()
 
 	header := c.GetHeader("Authorization")
@@ -139,3 +140,87 @@ func (h *ProfileHandler) CreateProfile(c *gin.Context) {
 
 	h.logger.Sugar().Infof("Profil: %v created", profile)
 }
+
+// UpdateProfile applies a JSON Merge Patch to the callers own profile.
+func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
+	profileId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	ownerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if ownerId != profileId {
+		writeProblem(c, http.StatusForbidden, "Forbidden", "profiles can only be updated by their owner")
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.Request)
+	defer cancel()
+
+	apply := func(current domain.Profile) (domain.Profile, error) {
+		merged, err := current.MergePatch(patch)
+		if err != nil {
+			return domain.Profile{}, fmt.Errorf("%w: %v", domain.ErrInvalidProfile, err)
+		}
+
+		if err := validator.ValidateProfile(merged); err != nil {
+			return domain.Profile{}, fmt.Errorf("%w: %v", domain.ErrInvalidProfile, err)
+		}
+
+		return merged, nil
+	}
+
+	updated, err := h.writerService.Update(profileId, apply, ctx)
+	if err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profile": updated,
+	})
+
+	h.logger.Sugar().Infof("Profile: %s updated", profileId)
+}
+
+// DeleteProfile removes the callers own profile along with all of its follows.
+func (h *ProfileHandler) DeleteProfile(c *gin.Context) {
+	profileId, ok := parseIdParam(c)
+	if !ok {
+		return
+	}
+
+	ownerId, ok := callerId(c, h.logger)
+	if !ok {
+		return
+	}
+
+	if ownerId != profileId {
+		writeProblem(c, http.StatusForbidden, "Forbidden", "profiles can only be deleted by their owner")
+		return
+	}
+
+	ctx := c.Request.Context()
+	ctx, cancel := context.WithTimeout(ctx, h.settings.Get().Timeouts.List)
+	defer cancel()
+
+	if err := h.writerService.Delete(profileId, ctx); err != nil {
+		writeError(c, ctx, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+
+	h.logger.Sugar().Infof("Profile: %s deleted", profileId)
+}
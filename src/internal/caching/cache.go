@@ -1,74 +1,71 @@
 package caching
 
 import (
-	"sync"
-	"sync/atomic"
+	"context"
 	"time"
-
-	"golang.org/x/sync/singleflight"
 )
 
+// Cache is what services read through, implemented in memory per replica, in redis shared by every replica,
+// or as a near cache holding a local copy of a shared redis cache.
+type Cache interface {
+	// GetOrCreate returns the value for key, loading it with createFn on a miss.
+	// Tags label the created entry so it can later be evicted with Invalidate.
+	GetOrCreate(key string, expiration time.Duration, createFn Loader, tags []string, ctx context.Context) (interface{}, error)
+	// Set stores value under key, replacing whatever was there.
+	Set(key string, value interface{}, expiration time.Duration, tags ...string)
+	// Delete removes key so the next read loads a fresh value.
+	Delete(key string)
+	// DeletePrefix removes every key starting with prefix and returns how many entries were dropped.
+	DeletePrefix(prefix string) int
+	// Invalidate removes every entry labelled with any of tags and returns how many entries were dropped.
+	Invalidate(tags ...string) int
+	// Cacheable reports whether err may be cached under the cache's error policy.
+	Cacheable(err error) bool
+	Stats() Stats
+	// Keys lists up to limit keys starting with prefix, in no particular order for shared backends.
+	Keys(prefix string, limit int) ([]string, error)
+	// Inspect describes the entry held for key without loading it or marking it as used.
+	Inspect(key string) (EntryInfo, bool, error)
+	// Stop releases the background work the cache runs, waiting for it to exit.
+	Stop()
+}
+
+// CacheEntry holds either a loaded value or, for a negative entry, the cacheable error the load failed with.
 type CacheEntry struct {
 	Value      interface{}
+	Err        error
 	Expiration time.Time
+	Tags       []string
 }
 
-type Cache struct {
-	data       sync.Map
-	group      singleflight.Group
-	itemCount  int32
-	stopChan   chan struct{}
-	isCleaning bool
-	cleanMu    sync.Mutex
+// Options bound the size of the cache and set its error caching policy, a zero value leaves that feature off.
+type Options struct {
+	// MaxEntries caps how many entries are held, the least recently used entry is evicted first.
+	MaxEntries int
+	// MaxBytes caps the approximate memory held by keys and values.
+	MaxBytes int64
+	// NegativeTTL is how long a cacheable error is remembered, errors are never cached when it is zero.
+	NegativeTTL time.Duration
+	// CacheableErrors are the errors, matched with errors.Is, a failed load may be cached for, such as not found.
+	CacheableErrors []error
+	// StaleTTL is how long past its expiry a value may still be served while a single background refresh runs.
+	StaleTTL time.Duration
+	// RefreshTimeout bounds a background refresh, ten seconds when zero.
+	RefreshTimeout time.Duration
+	// Jitter spreads every expiration by up to this fraction either way so hot keys don't expire together.
+	Jitter float64
 }
 
-func (c *Cache) GetOrCreate(key string, expiration time.Duration, createFn func() (interface{}, error)) (interface{}, error) {
-	if value, ok := c.data.Load(key); ok {
-		cacheEntry := value.(CacheEntry)
-		if cacheEntry.Expiration.After(time.Now()) {
-			return cacheEntry.Value, nil
-		} else {
-			c.Cleanup()
-		}
-	}
-
-	value, err, _ := c.group.Do(key, func() (interface{}, error) {
-		if value, ok := c.data.Load(key); ok {
-			cacheEntry := value.(CacheEntry)
-			if cacheEntry.Expiration.After(time.Now()) {
-				return cacheEntry.Value, nil
-			}
-		}
-
-		v, err := createFn()
-		if err != nil {
-			return nil, err
-		}
-
-		entry := CacheEntry{
-			Value:      v,
-			Expiration: time.Now().Add(expiration),
-		}
-
-		c.data.Store(key, entry)
-		atomic.AddInt32(&c.itemCount, 1)
-		return v, nil
-	})
-
-	return value, err
+// EntryInfo describes a cached entry for introspection.
+type EntryInfo struct {
+	Key        string    `json:"key"`
+	Expiration time.Time `json:"expiration"`
+	// StaleUntil is when the entry stops being served at all, it equals Expiration unless a StaleTTL is set.
+	StaleUntil time.Time `json:"stale_until"`
+	// Negative is set when the entry caches a load error rather than a value.
+	Negative bool     `json:"negative"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
-func (c *Cache) Cleanup() {
-	if atomic.LoadInt32(&c.itemCount) == 0 {
-		return
-	}
-
-	c.data.Range(func(key, value interface{}) bool {
-		entry := value.(CacheEntry)
-		if entry.Expiration.Before(time.Now()) {
-			c.data.Delete(key)
-			atomic.AddInt32(&c.itemCount, -1)
-		}
-		return true
-	})
-}
+// Loader loads the value for a missing or stale key.
+type Loader func(ctx context.Context) (interface{}, error)
@@ -0,0 +1,73 @@
+package caching
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Collector exports a cache's Stats as prometheus metrics, read fresh on every scrape.
+type Collector struct {
+	cache           Cache
+	hits            *prometheus.Desc
+	misses          *prometheus.Desc
+	evictions       *prometheus.Desc
+	loads           *prometheus.Desc
+	loadFailures    *prometheus.Desc
+	loadDuration    *prometheus.Desc
+	shared          *prometheus.Desc
+	staleServes     *prometheus.Desc
+	refreshes       *prometheus.Desc
+	refreshFailures *prometheus.Desc
+	entries         *prometheus.Desc
+}
+
+func NewCollector(cache Cache) *Collector {
+	return &Collector{
+		cache:           cache,
+		hits:            prometheus.NewDesc("cache_hits_total", "Reads answered from the cache.", nil, nil),
+		misses:          prometheus.NewDesc("cache_misses_total", "Reads that had to load a value.", nil, nil),
+		evictions:       prometheus.NewDesc("cache_evictions_total", "Entries evicted to stay within the size limits.", nil, nil),
+		loads:           prometheus.NewDesc("cache_loads_total", "Calls to a loader.", nil, nil),
+		loadFailures:    prometheus.NewDesc("cache_load_failures_total", "Calls to a loader that returned an error.", nil, nil),
+		loadDuration:    prometheus.NewDesc("cache_load_duration_seconds_total", "Time spent in loaders.", nil, nil),
+		shared:          prometheus.NewDesc("cache_singleflight_shared_total", "Reads that shared a load already in flight.", nil, nil),
+		staleServes:     prometheus.NewDesc("cache_stale_serves_total", "Reads answered with a stale value while it refreshed.", nil, nil),
+		refreshes:       prometheus.NewDesc("cache_refreshes_total", "Background refreshes of stale values.", nil, nil),
+		refreshFailures: prometheus.NewDesc("cache_refresh_failures_total", "Background refreshes that failed.", nil, nil),
+		entries:         prometheus.NewDesc("cache_entries", "Entries held in memory.", nil, nil),
+	}
+}
+
+func (c *Collector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- c.hits
+	descs <- c.misses
+	descs <- c.evictions
+	descs <- c.loads
+	descs <- c.loadFailures
+	descs <- c.loadDuration
+	descs <- c.shared
+	descs <- c.staleServes
+	descs <- c.refreshes
+	descs <- c.refreshFailures
+	descs <- c.entries
+}
+
+func (c *Collector) Collect(metrics chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+
+	counter := func(desc *prometheus.Desc, value uint64) {
+		metrics <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(value))
+	}
+
+	counter(c.hits, stats.Hits)
+	counter(c.misses, stats.Misses)
+	counter(c.evictions, stats.Evictions)
+	counter(c.loads, stats.Loads)
+	counter(c.loadFailures, stats.LoadFailures)
+	metrics <- prometheus.MustNewConstMetric(c.loadDuration, prometheus.CounterValue, stats.LoadDuration.Seconds())
+	counter(c.shared, stats.Shared)
+	counter(c.staleServes, stats.StaleServes)
+	counter(c.refreshes, stats.Refreshes)
+	counter(c.refreshFailures, stats.RefreshFailures)
+
+	if sized, ok := c.cache.(interface{ Len() int }); ok {
+		metrics <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(sized.Len()))
+	}
+}
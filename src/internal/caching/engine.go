@@ -0,0 +1,204 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const defaultRefreshTimeout = 10 * time.Second
+
+// entryState is how a lookup found a key.
+type entryState int
+
+const (
+	missing entryState = iota
+	fresh
+	stale
+)
+
+// backend is where an engine keeps its entries. save is handed the load that produced entry and must drop
+// the entry if that load was invalidated while it ran.
+type backend interface {
+	lookup(key string) (CacheEntry, bool)
+	save(key string, entry CacheEntry, load *pendingLoad)
+}
+
+// engine is the read path every Cache implementation shares: singleflight loads, negative caching,
+// stale-while-revalidate and jittered expirations over a backend.
+type engine struct {
+	options    Options
+	backend    backend
+	group      singleflight.Group
+	refreshMu  sync.Mutex
+	refreshing map[string]struct{}
+	refreshes  sync.WaitGroup
+	loads      inflight
+	stats      counters
+}
+
+// GetOrCreate returns the live value for key, calling createFn once across concurrent callers on a miss.
+// A load failing with a cacheable error is remembered for NegativeTTL and that error is returned to readers
+// until it expires. With a StaleTTL set, a value past its expiry is served as is while one background refresh
+// reloads it.
+func (e *engine) GetOrCreate(key string, expiration time.Duration, createFn Loader, tags []string, ctx context.Context) (interface{}, error) {
+	value, err, state := e.load(key)
+	switch state {
+	case fresh:
+		e.stats.hits.Add(1)
+		return value, err
+	case stale:
+		e.stats.staleServes.Add(1)
+		e.refresh(key, expiration, createFn, tags, ctx)
+		return value, err
+	}
+
+	e.stats.misses.Add(1)
+	value, err, shared := e.group.Do(key, func() (interface{}, error) {
+		if value, err, state := e.load(key); state == fresh {
+			return value, err
+		}
+
+		return e.create(key, expiration, createFn, tags, ctx)
+	})
+	if shared {
+		e.stats.shared.Add(1)
+	}
+
+	return value, err
+}
+
+// Cacheable reports whether err may be cached under the cache's error policy.
+func (e *engine) Cacheable(err error) bool {
+	if err == nil || e.options.NegativeTTL <= 0 {
+		return false
+	}
+
+	for _, cacheable := range e.options.CacheableErrors {
+		if errors.Is(err, cacheable) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// load returns the value or cached error for key along with whether it is fresh or only servable as stale.
+func (e *engine) load(key string) (interface{}, error, entryState) {
+	entry, ok := e.backend.lookup(key)
+	if !ok {
+		return nil, nil, missing
+	}
+
+	now := time.Now()
+	if !e.servableUntil(entry).After(now) {
+		return nil, nil, missing
+	}
+
+	if !entry.Expiration.After(now) {
+		return entry.Value, entry.Err, stale
+	}
+	return entry.Value, entry.Err, fresh
+}
+
+// create runs createFn and stores what it returns, or the error it failed with when that error is cacheable.
+func (e *engine) create(key string, expiration time.Duration, createFn Loader, tags []string, ctx context.Context) (interface{}, error) {
+	load := e.loads.begin(key, tags)
+	defer e.loads.finish(load)
+
+	started := time.Now()
+	v, err := createFn(ctx)
+	e.stats.loads.Add(1)
+	e.stats.loadNanos.Add(int64(time.Since(started)))
+	if err != nil {
+		e.stats.loadFailures.Add(1)
+		if e.Cacheable(err) {
+			e.backend.save(key, CacheEntry{
+				Err:        err,
+				Expiration: time.Now().Add(e.jitter(e.options.NegativeTTL)),
+				Tags:       tags,
+			}, load)
+		}
+		return nil, err
+	}
+
+	e.backend.save(key, CacheEntry{
+		Value:      v,
+		Expiration: time.Now().Add(e.jitter(expiration)),
+		Tags:       tags,
+	}, load)
+	return v, nil
+}
+
+// refresh reloads a stale key in the background, only one refresh per key runs at a time.
+// The refresh outlives the request that triggered it so it runs on a detached context bounded by RefreshTimeout.
+func (e *engine) refresh(key string, expiration time.Duration, createFn Loader, tags []string, ctx context.Context) {
+	e.refreshMu.Lock()
+	if _, ok := e.refreshing[key]; ok {
+		e.refreshMu.Unlock()
+		return
+	}
+	if e.refreshing == nil {
+		e.refreshing = make(map[string]struct{})
+	}
+	e.refreshing[key] = struct{}{}
+	e.refreshMu.Unlock()
+
+	timeout := e.options.RefreshTimeout
+	if timeout <= 0 {
+		timeout = defaultRefreshTimeout
+	}
+
+	e.refreshes.Add(1)
+	go func() {
+		defer e.refreshes.Done()
+		defer func() {
+			e.refreshMu.Lock()
+			delete(e.refreshing, key)
+			e.refreshMu.Unlock()
+		}()
+
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+
+		e.stats.refreshes.Add(1)
+		_, err, _ := e.group.Do(key, func() (interface{}, error) {
+			return e.create(key, expiration, createFn, tags, refreshCtx)
+		})
+		if err != nil {
+			e.stats.refreshFailures.Add(1)
+		}
+	}()
+}
+
+// servableUntil is when an entry can no longer be served at all, cached errors are never served stale.
+func (e *engine) servableUntil(entry CacheEntry) time.Time {
+	if entry.Err != nil {
+		return entry.Expiration
+	}
+	return entry.Expiration.Add(e.options.StaleTTL)
+}
+
+func (e *engine) describe(key string, entry CacheEntry) EntryInfo {
+	return EntryInfo{
+		Key:        key,
+		Expiration: entry.Expiration,
+		StaleUntil: e.servableUntil(entry),
+		Negative:   entry.Err != nil,
+		Tags:       entry.Tags,
+	}
+}
+
+// jitter spreads d by up to the Jitter fraction either way.
+func (e *engine) jitter(d time.Duration) time.Duration {
+	if e.options.Jitter <= 0 || d <= 0 {
+		return d
+	}
+
+	spread := float64(d) * e.options.Jitter
+	return d + time.Duration((rand.Float64()*2-1)*spread)
+}
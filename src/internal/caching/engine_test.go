@@ -0,0 +1,175 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errMissing = errors.New("missing")
+
+// countingLoader returns err, wrapped, from every call and counts them.
+func countingLoader(calls *int, err error) Loader {
+	return func(ctx context.Context) (interface{}, error) {
+		*calls++
+		if err != nil {
+			return nil, fmt.Errorf("load %d: %w", *calls, err)
+		}
+		return fmt.Sprintf("value %d", *calls), nil
+	}
+}
+
+func TestNegativeCaching(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		err     error
+		calls   int
+	}{
+		{"cacheable error", Options{NegativeTTL: time.Minute, CacheableErrors: []error{errMissing}}, errMissing, 1},
+		{"other error", Options{NegativeTTL: time.Minute, CacheableErrors: []error{errMissing}}, errors.New("down"), 3},
+		{"no negative ttl", Options{CacheableErrors: []error{errMissing}}, errMissing, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := NewMemoryCache(test.options)
+
+			calls := 0
+			for i := 0; i < 3; i++ {
+				_, err := cache.GetOrCreate("key", time.Minute, countingLoader(&calls, test.err), nil, context.Background())
+				if !errors.Is(err, test.err) {
+					t.Fatalf("read %d returned %v, want %v", i, err, test.err)
+				}
+			}
+
+			if calls != test.calls {
+				t.Errorf("loader called %d times, want %d", calls, test.calls)
+			}
+		})
+	}
+}
+
+func TestNegativeEntryExpiresAfterNegativeTTL(t *testing.T) {
+	cache := NewMemoryCache(Options{
+		NegativeTTL:     10 * time.Millisecond,
+		CacheableErrors: []error{errMissing},
+		StaleTTL:        time.Minute,
+	})
+
+	calls := 0
+	if _, err := cache.GetOrCreate("key", time.Minute, countingLoader(&calls, errMissing), nil, context.Background()); !errors.Is(err, errMissing) {
+		t.Fatalf("first read returned %v, want %v", err, errMissing)
+	}
+
+	info, ok, err := cache.Inspect("key")
+	if err != nil || !ok || !info.Negative {
+		t.Fatalf("Inspect() = %+v, %v, %v, want a negative entry", info, ok, err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	value, err := cache.GetOrCreate("key", time.Minute, countingLoader(&calls, nil), nil, context.Background())
+	if err != nil {
+		t.Fatalf("read after expiry returned %v", err)
+	}
+	if value != "value 2" {
+		t.Errorf("value = %v, want a fresh load rather than a stale error", value)
+	}
+}
+
+func TestStaleValueIsServedWhileOneRefreshRuns(t *testing.T) {
+	cache := NewMemoryCache(Options{StaleTTL: time.Minute})
+	cache.Set("key", "old", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	release := make(chan struct{})
+	var calls atomic.Int32
+	refresh := func(ctx context.Context) (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return "new", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := cache.GetOrCreate("key", time.Minute, refresh, nil, context.Background())
+		if err != nil || value != "old" {
+			t.Fatalf("stale read %d = %v, %v, want the old value", i, value, err)
+		}
+	}
+
+	close(release)
+	cache.Stop()
+
+	if calls.Load() != 1 {
+		t.Errorf("refresh ran %d times, want 1", calls.Load())
+	}
+
+	value, err := cache.GetOrCreate("key", time.Minute, func(ctx context.Context) (interface{}, error) {
+		t.Fatal("loader called for a refreshed key")
+		return nil, nil
+	}, nil, context.Background())
+	if err != nil || value != "new" {
+		t.Errorf("read after refresh = %v, %v, want the refreshed value", value, err)
+	}
+
+	stats := cache.Stats()
+	if stats.StaleServes != 3 || stats.Refreshes != 1 || stats.RefreshFailures != 0 {
+		t.Errorf("stats = %+v, want 3 stale serves and 1 refresh", stats)
+	}
+}
+
+func TestValuePastItsStaleWindowIsLoaded(t *testing.T) {
+	cache := NewMemoryCache(Options{StaleTTL: time.Millisecond})
+	cache.Set("key", "old", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	value, err := cache.GetOrCreate("key", time.Minute, func(ctx context.Context) (interface{}, error) {
+		return "new", nil
+	}, nil, context.Background())
+	if err != nil || value != "new" {
+		t.Errorf("read = %v, %v, want a synchronous load", value, err)
+	}
+}
+
+func TestJitterSpreadsExpirations(t *testing.T) {
+	cache := NewMemoryCache(Options{Jitter: 0.1})
+	earliest, latest := 54*time.Minute, 66*time.Minute+time.Second
+
+	started := time.Now()
+	expirations := make(map[time.Time]bool)
+	for i := 0; i < 50; i++ {
+		key := fmt.Sprintf("key %d", i)
+		if _, err := cache.GetOrCreate(key, time.Hour, func(ctx context.Context) (interface{}, error) {
+			return "value", nil
+		}, nil, context.Background()); err != nil {
+			t.Fatalf("loading %s: %v", key, err)
+		}
+
+		info, _, _ := cache.Inspect(key)
+		ttl := info.Expiration.Sub(started)
+		if ttl < earliest || ttl > latest {
+			t.Errorf("%s expires in %v, outside the jitter window", key, ttl)
+		}
+		expirations[info.Expiration] = true
+	}
+
+	if len(expirations) < 2 {
+		t.Error("every entry expires at the same moment")
+	}
+}
+
+func TestJitterLeavesDurationsAloneWhenOff(t *testing.T) {
+	cache := NewMemoryCache(Options{})
+	if got := cache.jitter(time.Hour); got != time.Hour {
+		t.Errorf("jitter(1h) = %v, want 1h", got)
+	}
+
+	cache = NewMemoryCache(Options{Jitter: 0.5})
+	if got := cache.jitter(0); got != 0 {
+		t.Errorf("jitter(0) = %v, want 0", got)
+	}
+}
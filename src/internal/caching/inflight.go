@@ -0,0 +1,75 @@
+package caching
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// pendingLoad is a load in flight for key, tags are the ones its result will be stored under.
+type pendingLoad struct {
+	key         string
+	tags        []string
+	invalidated bool
+}
+
+// inflight tracks the loads running so an invalidation only drops the results it could have made stale.
+// A load whose key, prefix or tags are invalidated while it runs may have read data from before the
+// invalidation, so its result is thrown away instead of stored. Loads for anything else are left alone.
+type inflight struct {
+	mu    sync.Mutex
+	loads map[*pendingLoad]struct{}
+}
+
+func (f *inflight) begin(key string, tags []string) *pendingLoad {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.loads == nil {
+		f.loads = make(map[*pendingLoad]struct{})
+	}
+
+	load := &pendingLoad{key: key, tags: tags}
+	f.loads[load] = struct{}{}
+	return load
+}
+
+// finish forgets load and reports whether its result may still be stored, finishing a load twice is harmless.
+func (f *inflight) finish(load *pendingLoad) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.loads, load)
+	return !load.invalidated
+}
+
+func (f *inflight) invalidateKey(key string) {
+	f.invalidate(func(load *pendingLoad) bool {
+		return load.key == key
+	})
+}
+
+func (f *inflight) invalidatePrefix(prefix string) {
+	f.invalidate(func(load *pendingLoad) bool {
+		return strings.HasPrefix(load.key, prefix)
+	})
+}
+
+func (f *inflight) invalidateTags(tags ...string) {
+	f.invalidate(func(load *pendingLoad) bool {
+		return slices.ContainsFunc(load.tags, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	})
+}
+
+func (f *inflight) invalidate(affected func(load *pendingLoad) bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for load := range f.loads {
+		if affected(load) {
+			load.invalidated = true
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package caching
+
+import (
+	"sort"
+	"strings"
+)
+
+// Keys lists up to limit keys starting with prefix in order, a limit of zero or less lists them all.
+func (c *MemoryCache) Keys(prefix string, limit int) ([]string, error) {
+	c.mu.Lock()
+	keys := make([]string, 0)
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Strings(keys)
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	return keys, nil
+}
+
+// Inspect describes the entry held for key, including an expired entry the janitor hasn't swept yet.
+func (c *MemoryCache) Inspect(key string) (EntryInfo, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return EntryInfo{}, false, nil
+	}
+
+	return c.describe(key, element.Value.(*lruItem).entry), true, nil
+}
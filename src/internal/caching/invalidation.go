@@ -0,0 +1,80 @@
+package caching
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UserTag is the tag every entry holding data about a user is labelled with, invalidating it evicts them all.
+func UserTag(id fmt.Stringer) string {
+	return "user:" + id.String()
+}
+
+// Set stores value under key, replacing whatever was there.
+func (c *MemoryCache) Set(key string, value interface{}, expiration time.Duration, tags ...string) {
+	c.group.Forget(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads.invalidateKey(key)
+	c.put(key, CacheEntry{
+		Value:      value,
+		Expiration: time.Now().Add(expiration),
+		Tags:       tags,
+	})
+}
+
+// Delete removes key from the cache so the next read loads a fresh value.
+func (c *MemoryCache) Delete(key string) {
+	c.group.Forget(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads.invalidateKey(key)
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// DeletePrefix removes every key starting with prefix and returns how many entries were dropped.
+func (c *MemoryCache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads.invalidatePrefix(prefix)
+
+	removed := 0
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.group.Forget(key)
+			c.removeElement(element)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Invalidate removes every entry labelled with any of tags and returns how many entries were dropped.
+func (c *MemoryCache) Invalidate(tags ...string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads.invalidateTags(tags...)
+
+	removed := 0
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if element, ok := c.entries[key]; ok {
+				c.group.Forget(key)
+				c.removeElement(element)
+				removed++
+			}
+		}
+	}
+
+	return removed
+}
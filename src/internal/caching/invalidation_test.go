@@ -0,0 +1,58 @@
+package caching
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// loadDuring runs a load for key tagged with tags, calling during while it is in flight, and reports
+// whether its result was stored.
+func loadDuring(t *testing.T, cache *MemoryCache, key string, tags []string, during func()) bool {
+	t.Helper()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.GetOrCreate(key, time.Minute, func(ctx context.Context) (interface{}, error) {
+			close(started)
+			<-release
+			return "loaded", nil
+		}, tags, context.Background())
+	}()
+
+	<-started
+	during()
+	close(release)
+	<-done
+
+	_, ok := cache.lookup(key)
+	return ok
+}
+
+func TestInvalidationOnlyDropsLoadsItAffects(t *testing.T) {
+	tests := []struct {
+		name   string
+		during func(cache *MemoryCache)
+		stored bool
+	}{
+		{"unrelated delete", func(cache *MemoryCache) { cache.Delete("other") }, true},
+		{"unrelated prefix", func(cache *MemoryCache) { cache.DeletePrefix("other:") }, true},
+		{"unrelated tag", func(cache *MemoryCache) { cache.Invalidate("user:2") }, true},
+		{"same key", func(cache *MemoryCache) { cache.Delete("profile:1") }, false},
+		{"matching prefix", func(cache *MemoryCache) { cache.DeletePrefix("profile:") }, false},
+		{"matching tag", func(cache *MemoryCache) { cache.Invalidate("user:1") }, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := NewMemoryCache(Options{})
+			stored := loadDuring(t, cache, "profile:1", []string{"user:1"}, func() { test.during(cache) })
+			if stored != test.stored {
+				t.Errorf("stored = %v, want %v", stored, test.stored)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package caching
+
+import "time"
+
+// Start runs Cleanup every interval on a background goroutine until Stop is called, starting twice is a no-op.
+func (c *MemoryCache) Start(interval time.Duration) {
+	c.cleanMu.Lock()
+	defer c.cleanMu.Unlock()
+
+	if c.isCleaning {
+		return
+	}
+
+	c.isCleaning = true
+	c.stopChan = make(chan struct{})
+
+	c.janitor.Add(1)
+	go c.runJanitor(interval, c.stopChan)
+}
+
+// Stop halts the janitor and waits for its goroutine and any background refresh to exit, it is safe to call
+// when the janitor isn't running.
+func (c *MemoryCache) Stop() {
+	c.cleanMu.Lock()
+	defer c.cleanMu.Unlock()
+
+	defer c.refreshes.Wait()
+
+	if !c.isCleaning {
+		return
+	}
+
+	close(c.stopChan)
+	c.janitor.Wait()
+	c.isCleaning = false
+}
+
+func (c *MemoryCache) runJanitor(interval time.Duration, stop <-chan struct{}) {
+	defer c.janitor.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Cleanup()
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package caching
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/goleak"
+)
+
+func TestJanitorSweepsExpiredEntriesAndStops(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cache := NewMemoryCache(Options{})
+	cache.Set("expiring", "value", 10*time.Millisecond)
+	cache.Set("kept", "value", time.Hour)
+
+	cache.Start(5 * time.Millisecond)
+
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("janitor didn't sweep the expired entry, %d entries held", cache.Len())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cache.Stop()
+}
+
+func TestJanitorStartTwiceRunsOneGoroutine(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cache := NewMemoryCache(Options{})
+	cache.Start(time.Millisecond)
+	cache.Start(time.Millisecond)
+	cache.Stop()
+}
+
+func TestJanitorStopTwice(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cache := NewMemoryCache(Options{})
+	cache.Start(time.Millisecond)
+	cache.Stop()
+	cache.Stop()
+}
+
+func TestJanitorStopWithoutStart(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cache := NewMemoryCache(Options{})
+	cache.Stop()
+}
+
+func TestJanitorRestartAfterStop(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	cache := NewMemoryCache(Options{})
+	cache.Start(time.Millisecond)
+	cache.Stop()
+	cache.Start(time.Millisecond)
+	cache.Stop()
+}
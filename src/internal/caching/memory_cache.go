@@ -0,0 +1,171 @@
+package caching
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MemoryCache is a bounded in-process Cache, each replica holds its own entries.
+type MemoryCache struct {
+	engine
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	tags       map[string]map[string]struct{}
+	bytes      int64
+	itemCount  int32
+	stopChan   chan struct{}
+	isCleaning bool
+	cleanMu    sync.Mutex
+	janitor    sync.WaitGroup
+}
+
+// lruItem is what the lru list holds, the front of the list is the most recently used entry.
+type lruItem struct {
+	key   string
+	entry CacheEntry
+	size  int64
+}
+
+func NewMemoryCache(options Options) *MemoryCache {
+	c := &MemoryCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		tags:    make(map[string]map[string]struct{}),
+	}
+	c.options = options
+	c.backend = c
+
+	return c
+}
+
+// Len returns the number of entries held, including expired entries not yet cleaned up.
+func (c *MemoryCache) Len() int {
+	return int(atomic.LoadInt32(&c.itemCount))
+}
+
+func (c *MemoryCache) Cleanup() {
+	if atomic.LoadInt32(&c.itemCount) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for element := c.lru.Back(); element != nil; {
+		previous := element.Prev()
+		if !c.servableUntil(element.Value.(*lruItem).entry).After(now) {
+			c.removeElement(element)
+		}
+		element = previous
+	}
+}
+
+// lookup returns the entry for key, an entry past its stale window is dropped on the spot.
+func (c *MemoryCache) lookup(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	item := element.Value.(*lruItem)
+	if !c.servableUntil(item.entry).After(time.Now()) {
+		c.removeElement(element)
+		return CacheEntry{}, false
+	}
+
+	c.lru.MoveToFront(element)
+	return item.entry, true
+}
+
+// save stores entry unless its key or tags were invalidated while load ran, in which case the value may
+// already be stale and is dropped rather than cached. Invalidations mark loads holding mu, so none can slip
+// in between the check and the put.
+func (c *MemoryCache) save(key string, entry CacheEntry, load *pendingLoad) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loads.finish(load) {
+		return
+	}
+
+	c.put(key, entry)
+}
+
+// put saves entry, it must be called holding mu.
+func (c *MemoryCache) put(key string, entry CacheEntry) {
+	size := approximateSize(key, entry.Value)
+	if entry.Err != nil {
+		size = approximateSize(key, entry.Err.Error())
+	}
+
+	if element, ok := c.entries[key]; ok {
+		item := element.Value.(*lruItem)
+		c.untag(item)
+		c.bytes += size - item.size
+		item.entry = entry
+		item.size = size
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[key] = c.lru.PushFront(&lruItem{key: key, entry: entry, size: size})
+		c.bytes += size
+		atomic.AddInt32(&c.itemCount, 1)
+	}
+
+	for _, tag := range entry.Tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	c.evict()
+}
+
+// evict drops least recently used entries until the cache is back within its limits, it must be called holding mu.
+func (c *MemoryCache) evict() {
+	for c.overLimit() {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			return
+		}
+		c.removeElement(oldest)
+		c.stats.evictions.Add(1)
+	}
+}
+
+func (c *MemoryCache) overLimit() bool {
+	if c.options.MaxEntries > 0 && c.lru.Len() > c.options.MaxEntries {
+		return true
+	}
+
+	return c.options.MaxBytes > 0 && c.bytes > c.options.MaxBytes
+}
+
+// removeElement unlinks an entry, it must be called holding mu.
+func (c *MemoryCache) removeElement(element *list.Element) {
+	item := c.lru.Remove(element).(*lruItem)
+	delete(c.entries, item.key)
+	c.untag(item)
+	c.bytes -= item.size
+	atomic.AddInt32(&c.itemCount, -1)
+}
+
+// untag drops the entry from the tag index, it must be called holding mu.
+func (c *MemoryCache) untag(item *lruItem) {
+	for _, tag := range item.entry.Tags {
+		keys := c.tags[tag]
+		delete(keys, item.key)
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package caching
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// held lists which of keys the cache holds, read with Inspect so the lru order isn't touched.
+func held(t *testing.T, cache *MemoryCache, keys ...string) map[string]bool {
+	t.Helper()
+
+	found := make(map[string]bool)
+	for _, key := range keys {
+		_, ok, err := cache.Inspect(key)
+		if err != nil {
+			t.Fatalf("inspecting %s: %v", key, err)
+		}
+		found[key] = ok
+	}
+	return found
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(Options{MaxEntries: 2})
+	cache.Set("a", "value", time.Hour)
+	cache.Set("b", "value", time.Hour)
+
+	if _, err := cache.GetOrCreate("a", time.Hour, func(ctx context.Context) (interface{}, error) {
+		t.Fatal("loader called for a cached key")
+		return nil, nil
+	}, nil, context.Background()); err != nil {
+		t.Fatalf("reading a: %v", err)
+	}
+
+	cache.Set("c", "value", time.Hour)
+
+	found := held(t, cache, "a", "b", "c")
+	if !found["a"] || found["b"] || !found["c"] {
+		t.Errorf("held = %v, want a and c with b evicted as least recently used", found)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+	if evictions := cache.Stats().Evictions; evictions != 1 {
+		t.Errorf("Evictions = %d, want 1", evictions)
+	}
+}
+
+func TestMemoryCacheEvictsToStayWithinMaxBytes(t *testing.T) {
+	entrySize := approximateSize("a", "value")
+	cache := NewMemoryCache(Options{MaxBytes: 2 * entrySize})
+
+	cache.Set("a", "value", time.Hour)
+	cache.Set("b", "value", time.Hour)
+	cache.Set("c", "value", time.Hour)
+
+	found := held(t, cache, "a", "b", "c")
+	if found["a"] || !found["b"] || !found["c"] {
+		t.Errorf("held = %v, want b and c with a evicted", found)
+	}
+	if cache.bytes > cache.options.MaxBytes {
+		t.Errorf("bytes = %d, over the %d limit", cache.bytes, cache.options.MaxBytes)
+	}
+}
+
+func TestMemoryCacheReplacingAnEntryTracksItsNewSize(t *testing.T) {
+	cache := NewMemoryCache(Options{MaxBytes: 1 << 20})
+
+	cache.Set("a", strings.Repeat("x", 4096), time.Hour)
+	cache.Set("a", "small", time.Hour)
+
+	if want := approximateSize("a", "small"); cache.bytes != want {
+		t.Errorf("bytes = %d, want %d", cache.bytes, want)
+	}
+
+	cache.Delete("a")
+	if cache.bytes != 0 {
+		t.Errorf("bytes = %d after delete, want 0", cache.bytes)
+	}
+}
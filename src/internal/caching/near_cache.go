@@ -0,0 +1,170 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// NearCache keeps a local MemoryCache in front of a shared RedisCache. Reads are served locally when possible,
+// and every invalidation is applied to both tiers then published so other replicas drop their local copies.
+type NearCache struct {
+	local   *MemoryCache
+	remote  *RedisCache
+	client  redis.UniversalClient
+	channel string
+	origin  string
+	pubsub  *redis.PubSub
+	stop    sync.Once
+	done    sync.WaitGroup
+}
+
+// invalidation is the message replicas exchange, a replica ignores the messages it published itself.
+type invalidation struct {
+	Origin   string   `json:"origin"`
+	Keys     []string `json:"keys,omitempty"`
+	Prefixes []string `json:"prefixes,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// NewNearCache subscribes to channel for invalidations from other replicas, the subscription lasts until Stop.
+func NewNearCache(local *MemoryCache, remote *RedisCache, client redis.UniversalClient, channel string) (*NearCache, error) {
+	c := &NearCache{
+		local:   local,
+		remote:  remote,
+		client:  client,
+		channel: channel,
+		origin:  uuid.NewString(),
+	}
+
+	ctx, cancel := remote.context()
+	defer cancel()
+
+	c.pubsub = client.Subscribe(ctx, channel)
+	if _, err := c.pubsub.Receive(ctx); err != nil {
+		c.pubsub.Close()
+		return nil, fmt.Errorf("subscribing to %s: %w", channel, err)
+	}
+
+	c.done.Add(1)
+	go c.listen()
+
+	return c, nil
+}
+
+func (c *NearCache) GetOrCreate(key string, expiration time.Duration, createFn Loader, tags []string, ctx context.Context) (interface{}, error) {
+	return c.local.GetOrCreate(key, expiration, func(ctx context.Context) (interface{}, error) {
+		return c.remote.GetOrCreate(key, expiration, createFn, tags, ctx)
+	}, tags, ctx)
+}
+
+func (c *NearCache) Set(key string, value interface{}, expiration time.Duration, tags ...string) {
+	c.local.Set(key, value, expiration, tags...)
+	c.remote.Set(key, value, expiration, tags...)
+	c.publish(invalidation{Keys: []string{key}})
+}
+
+func (c *NearCache) Delete(key string) {
+	c.local.Delete(key)
+	c.remote.Delete(key)
+	c.publish(invalidation{Keys: []string{key}})
+}
+
+func (c *NearCache) DeletePrefix(prefix string) int {
+	c.local.DeletePrefix(prefix)
+	removed := c.remote.DeletePrefix(prefix)
+	c.publish(invalidation{Prefixes: []string{prefix}})
+
+	return removed
+}
+
+func (c *NearCache) Invalidate(tags ...string) int {
+	c.local.Invalidate(tags...)
+	removed := c.remote.Invalidate(tags...)
+	c.publish(invalidation{Tags: tags})
+
+	return removed
+}
+
+func (c *NearCache) Cacheable(err error) bool {
+	return c.remote.Cacheable(err)
+}
+
+// Stats reports the local tier, which is where reads are answered.
+func (c *NearCache) Stats() Stats {
+	return c.local.Stats()
+}
+
+// Keys lists keys from the shared tier, which every replica's local tier is a subset of.
+func (c *NearCache) Keys(prefix string, limit int) ([]string, error) {
+	return c.remote.Keys(prefix, limit)
+}
+
+func (c *NearCache) Inspect(key string) (EntryInfo, bool, error) {
+	return c.remote.Inspect(key)
+}
+
+// Len returns the number of entries held in the local tier.
+func (c *NearCache) Len() int {
+	return c.local.Len()
+}
+
+// Stop ends the subscription and stops both tiers.
+func (c *NearCache) Stop() {
+	c.stop.Do(func() {
+		c.pubsub.Close()
+		c.done.Wait()
+	})
+
+	c.local.Stop()
+	c.remote.Stop()
+}
+
+func (c *NearCache) publish(message invalidation) {
+	message.Origin = c.origin
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		c.remote.report(fmt.Errorf("encoding invalidation: %w", err))
+		return
+	}
+
+	ctx, cancel := c.remote.context()
+	defer cancel()
+
+	if err := c.client.Publish(ctx, c.channel, data).Err(); err != nil {
+		c.remote.report(fmt.Errorf("publishing invalidation: %w", err))
+	}
+}
+
+// listen applies invalidations published by other replicas to the local tier until the subscription closes.
+func (c *NearCache) listen() {
+	defer c.done.Done()
+
+	for message := range c.pubsub.Channel() {
+		var received invalidation
+		if err := json.Unmarshal([]byte(message.Payload), &received); err != nil {
+			c.remote.report(fmt.Errorf("decoding invalidation: %w", err))
+			continue
+		}
+
+		if received.Origin == c.origin {
+			continue
+		}
+
+		for _, key := range received.Keys {
+			c.local.Delete(key)
+		}
+		for _, prefix := range received.Prefixes {
+			c.local.DeletePrefix(prefix)
+		}
+		if len(received.Tags) > 0 {
+			c.local.Invalidate(received.Tags...)
+		}
+	}
+}
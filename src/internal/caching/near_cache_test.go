@@ -0,0 +1,98 @@
+package caching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestNearCache builds one replica's near cache, each replica gets its own client as it would in production.
+func newTestNearCache(t *testing.T, addr string) *NearCache {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+
+	remote := newTestRedisCache(t, client)
+	cache, err := NewNearCache(NewMemoryCache(Options{}), remote, client, "test:invalidations")
+	if err != nil {
+		t.Fatalf("NewNearCache: %v", err)
+	}
+	t.Cleanup(cache.Stop)
+
+	return cache
+}
+
+// eventually polls condition until it holds or a second has passed.
+func eventually(t *testing.T, condition func() bool, format string, args ...any) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNearCacheInvalidationEvictsOtherReplicas(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(cache *NearCache)
+	}{
+		{"delete", func(cache *NearCache) { cache.Delete("profile:1") }},
+		{"delete prefix", func(cache *NearCache) { cache.DeletePrefix("profile:") }},
+		{"invalidate tag", func(cache *NearCache) { cache.Invalidate("user:1") }},
+		{"set", func(cache *NearCache) { cache.Set("profile:1", testProfile{Name: "updated"}, time.Minute, "user:1") }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, _ := newTestRedis(t)
+			first := newTestNearCache(t, server.Addr())
+			second := newTestNearCache(t, server.Addr())
+			ctx := context.Background()
+
+			loads := 0
+			load := func(ctx context.Context) (testProfile, error) {
+				loads++
+				return testProfile{Name: "one"}, nil
+			}
+
+			for _, cache := range []*NearCache{first, second} {
+				if _, err := GetOrCreate(cache, "profile:1", time.Minute, load, []string{"user:1"}, ctx); err != nil {
+					t.Fatalf("GetOrCreate: %v", err)
+				}
+			}
+			if loads != 1 {
+				t.Fatalf("%d loads across both replicas, want the second read from redis", loads)
+			}
+			if _, ok := second.local.lookup("profile:1"); !ok {
+				t.Fatal("second replica didn't keep a local copy")
+			}
+
+			test.invalidate(first)
+
+			eventually(t, func() bool {
+				_, ok := second.local.lookup("profile:1")
+				return !ok
+			}, "second replica still holds its local copy after the first invalidated it")
+		})
+	}
+}
+
+func TestNearCacheIgnoresItsOwnInvalidations(t *testing.T) {
+	server, _ := newTestRedis(t)
+	cache := newTestNearCache(t, server.Addr())
+
+	cache.Set("profile:1", testProfile{Name: "one"}, time.Minute)
+
+	// a replica's own message arrives after Set, applying it would throw away the value just written
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := cache.local.lookup("profile:1"); !ok {
+		t.Error("replica dropped its own write on receiving its own invalidation")
+	}
+}
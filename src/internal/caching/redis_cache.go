@@ -0,0 +1,389 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRedisTimeout = 5 * time.Second
+
+// MinRedisVersion is the oldest major redis version RedisCache works with, tag indexes extend their ttl with
+// EXPIRE NX and GT which arrived in redis 7.
+const MinRedisVersion = 7
+
+// RedisOptions configure where a RedisCache keeps its entries.
+type RedisOptions struct {
+	// KeyPrefix namespaces every key the cache writes so other services can share the server.
+	KeyPrefix string
+	// Timeout bounds each call to redis, five seconds when zero.
+	Timeout time.Duration
+	// OnError is told about redis failures that can't be returned to a caller, a failed read is treated as a miss.
+	OnError func(error)
+}
+
+// RedisCache is a Cache shared by every replica. Values are stored as json and read back as Encoded, so they
+// should be read through GetOrCreate or a TypedCache. Tag indexes need redis 7 or later, check the server
+// with CheckRedisVersion before relying on it.
+type RedisCache struct {
+	engine
+	client       redis.UniversalClient
+	redisOptions RedisOptions
+}
+
+// redisEntry is how an entry is stored in redis. A cached error is stored as its message plus which of the
+// CacheableErrors it matched, so every replica must share the same error policy.
+type redisEntry struct {
+	Value      json.RawMessage `json:"value,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	ErrorKind  int             `json:"errorKind,omitempty"`
+	Expiration time.Time       `json:"expiration"`
+	Tags       []string        `json:"tags,omitempty"`
+}
+
+// cachedError is a negative entry read back from redis, it still matches the cacheable error it was stored for.
+type cachedError struct {
+	message string
+	target  error
+}
+
+func (e *cachedError) Error() string {
+	return e.message
+}
+
+func (e *cachedError) Unwrap() error {
+	return e.target
+}
+
+func NewRedisCache(client redis.UniversalClient, options Options, redisOptions RedisOptions) *RedisCache {
+	c := &RedisCache{
+		client:       client,
+		redisOptions: redisOptions,
+	}
+	c.options = options
+	c.backend = c
+
+	return c
+}
+
+// CheckRedisVersion fails unless the server is at least MinRedisVersion. The version is read from HELLO, a
+// server too old to know HELLO fails the check too.
+func CheckRedisVersion(client redis.UniversalClient, ctx context.Context) error {
+	// protocol 3 is what go-redis speaks by default, so asking for it leaves the connection as it was
+	reply, err := client.Do(ctx, "HELLO", 3).Result()
+	if err != nil {
+		return fmt.Errorf("reading the redis version, redis %d or later is required: %w", MinRedisVersion, err)
+	}
+
+	version := helloField(reply, "version")
+	major, _, _ := strings.Cut(version, ".")
+	if number, err := strconv.Atoi(major); err != nil || number < MinRedisVersion {
+		return fmt.Errorf("redis %q is not supported, redis %d or later is required", version, MinRedisVersion)
+	}
+
+	return nil
+}
+
+// helloField reads field from a HELLO reply, which is a map under RESP3 and a flat list of pairs under RESP2.
+func helloField(reply interface{}, field string) string {
+	switch reply := reply.(type) {
+	case map[interface{}]interface{}:
+		if value, ok := reply[field]; ok {
+			return fmt.Sprint(value)
+		}
+	case []interface{}:
+		for i := 0; i+1 < len(reply); i += 2 {
+			if reply[i] == field {
+				return fmt.Sprint(reply[i+1])
+			}
+		}
+	}
+	return ""
+}
+
+// Set stores value under key, replacing whatever was there.
+func (c *RedisCache) Set(key string, value interface{}, expiration time.Duration, tags ...string) {
+	c.loads.invalidateKey(key)
+	c.group.Forget(key)
+
+	c.write(key, CacheEntry{
+		Value:      value,
+		Expiration: time.Now().Add(expiration),
+		Tags:       tags,
+	})
+}
+
+// Delete removes key from the cache so the next read loads a fresh value.
+func (c *RedisCache) Delete(key string) {
+	c.loads.invalidateKey(key)
+	c.group.Forget(key)
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	if err := c.client.Del(ctx, c.key(key)).Err(); err != nil {
+		c.report(fmt.Errorf("deleting %s: %w", key, err))
+	}
+}
+
+// DeletePrefix removes every key starting with prefix and returns how many entries were dropped.
+func (c *RedisCache) DeletePrefix(prefix string) int {
+	c.loads.invalidatePrefix(prefix)
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	removed := 0
+	iter := c.client.Scan(ctx, 0, escapePattern(c.key(prefix))+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if strings.HasPrefix(key, c.tagKey("")) {
+			continue
+		}
+
+		c.group.Forget(strings.TrimPrefix(key, c.redisOptions.KeyPrefix))
+		deleted, err := c.client.Del(ctx, key).Result()
+		if err != nil {
+			c.report(fmt.Errorf("deleting %s: %w", key, err))
+			continue
+		}
+		removed += int(deleted)
+	}
+	if err := iter.Err(); err != nil {
+		c.report(fmt.Errorf("scanning for prefix %s: %w", prefix, err))
+	}
+
+	return removed
+}
+
+// Invalidate removes every entry labelled with any of tags and returns how many entries were dropped.
+func (c *RedisCache) Invalidate(tags ...string) int {
+	c.loads.invalidateTags(tags...)
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	removed := 0
+	for _, tag := range tags {
+		keys, err := c.client.SMembers(ctx, c.tagKey(tag)).Result()
+		if err != nil {
+			c.report(fmt.Errorf("reading tag %s: %w", tag, err))
+			continue
+		}
+
+		for _, key := range keys {
+			c.group.Forget(key)
+		}
+
+		redisKeys := make([]string, 0, len(keys))
+		for _, key := range keys {
+			redisKeys = append(redisKeys, c.key(key))
+		}
+
+		if len(redisKeys) > 0 {
+			deleted, err := c.client.Del(ctx, redisKeys...).Result()
+			if err != nil {
+				c.report(fmt.Errorf("invalidating tag %s: %w", tag, err))
+				continue
+			}
+			removed += int(deleted)
+		}
+
+		if err := c.client.Del(ctx, c.tagKey(tag)).Err(); err != nil {
+			c.report(fmt.Errorf("deleting tag %s: %w", tag, err))
+		}
+	}
+
+	return removed
+}
+
+// Keys lists up to limit keys starting with prefix, a limit of zero or less lists them all.
+func (c *RedisCache) Keys(prefix string, limit int) ([]string, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	keys := make([]string, 0)
+	iter := c.client.Scan(ctx, 0, escapePattern(c.key(prefix))+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if strings.HasPrefix(iter.Val(), c.tagKey("")) {
+			continue
+		}
+
+		keys = append(keys, strings.TrimPrefix(iter.Val(), c.redisOptions.KeyPrefix))
+		if limit > 0 && len(keys) == limit {
+			break
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("scanning for prefix %s: %w", prefix, err)
+	}
+
+	return keys, nil
+}
+
+// Inspect describes the entry held for key.
+func (c *RedisCache) Inspect(key string) (EntryInfo, bool, error) {
+	entry, ok, err := c.read(key)
+	if err != nil || !ok {
+		return EntryInfo{}, false, err
+	}
+
+	return c.describe(key, entry), true, nil
+}
+
+// Stop waits for background refreshes to finish, the redis client is left open for its owner to close.
+func (c *RedisCache) Stop() {
+	c.refreshes.Wait()
+}
+
+// lookup reads key for the engine, a failed read is reported and treated as a miss.
+func (c *RedisCache) lookup(key string) (CacheEntry, bool) {
+	entry, ok, err := c.read(key)
+	if err != nil {
+		c.report(err)
+		return CacheEntry{}, false
+	}
+
+	return entry, ok
+}
+
+func (c *RedisCache) read(key string) (CacheEntry, bool, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return CacheEntry{}, false, nil
+		}
+		return CacheEntry{}, false, fmt.Errorf("reading %s: %w", key, err)
+	}
+
+	var stored redisEntry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return CacheEntry{}, false, fmt.Errorf("decoding %s: %w", key, err)
+	}
+
+	entry := CacheEntry{
+		Expiration: stored.Expiration,
+		Tags:       stored.Tags,
+	}
+
+	if stored.Error != "" {
+		if stored.ErrorKind < 1 || stored.ErrorKind > len(c.options.CacheableErrors) {
+			return CacheEntry{}, false, nil
+		}
+		entry.Err = &cachedError{message: stored.Error, target: c.options.CacheableErrors[stored.ErrorKind-1]}
+		return entry, true, nil
+	}
+
+	entry.Value = Encoded(stored.Value)
+	return entry, true, nil
+}
+
+// save stores entry unless this replica invalidated its key or tags while load ran.
+func (c *RedisCache) save(key string, entry CacheEntry, load *pendingLoad) {
+	if !c.loads.finish(load) {
+		return
+	}
+
+	c.write(key, entry)
+}
+
+// write stores entry for as long as it can be served and adds it to its tag indexes.
+func (c *RedisCache) write(key string, entry CacheEntry) {
+	ttl := time.Until(c.servableUntil(entry))
+	if ttl <= 0 {
+		return
+	}
+
+	stored := redisEntry{
+		Expiration: entry.Expiration,
+		Tags:       entry.Tags,
+	}
+
+	if entry.Err != nil {
+		stored.Error = entry.Err.Error()
+		stored.ErrorKind = c.errorKind(entry.Err)
+	} else {
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			c.report(fmt.Errorf("encoding %s: %w", key, err))
+			return
+		}
+		stored.Value = value
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		c.report(fmt.Errorf("encoding %s: %w", key, err))
+		return
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.key(key), data, ttl)
+		for _, tag := range entry.Tags {
+			pipe.SAdd(ctx, c.tagKey(tag), key)
+			pipe.ExpireNX(ctx, c.tagKey(tag), ttl)
+			pipe.ExpireGT(ctx, c.tagKey(tag), ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		c.report(fmt.Errorf("writing %s: %w", key, err))
+	}
+}
+
+// errorKind is the position, counted from one, of the first cacheable error err matches.
+func (c *RedisCache) errorKind(err error) int {
+	for i, cacheable := range c.options.CacheableErrors {
+		if errors.Is(err, cacheable) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (c *RedisCache) key(key string) string {
+	return c.redisOptions.KeyPrefix + key
+}
+
+func (c *RedisCache) tagKey(tag string) string {
+	return c.redisOptions.KeyPrefix + "tag:" + tag
+}
+
+func (c *RedisCache) context() (context.Context, context.CancelFunc) {
+	timeout := c.redisOptions.Timeout
+	if timeout <= 0 {
+		timeout = defaultRedisTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func (c *RedisCache) report(err error) {
+	if c.redisOptions.OnError != nil {
+		c.redisOptions.OnError(err)
+	}
+}
+
+// escapePattern escapes the glob characters redis SCAN MATCH understands.
+func escapePattern(pattern string) string {
+	var escaped strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
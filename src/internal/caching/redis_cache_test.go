@@ -0,0 +1,209 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var errTestNotFound = errors.New("not found")
+
+type testProfile struct {
+	Name      string   `json:"name"`
+	Followers int      `json:"followers"`
+	Tags      []string `json:"tags"`
+}
+
+// newTestRedis starts an in-process redis and returns a client for it, both are closed when the test ends.
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, redis.UniversalClient) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return server, client
+}
+
+func newTestRedisCache(t *testing.T, client redis.UniversalClient) *RedisCache {
+	t.Helper()
+
+	cache := NewRedisCache(client, Options{
+		NegativeTTL:     time.Minute,
+		CacheableErrors: []error{errTestNotFound},
+	}, RedisOptions{
+		KeyPrefix: "test:",
+		OnError:   func(err error) { t.Errorf("redis error: %v", err) },
+	})
+	t.Cleanup(cache.Stop)
+
+	return cache
+}
+
+// failingLoader fails the test if the cache calls it, for reads that must be answered from redis.
+func failingLoader[V any](t *testing.T) func(ctx context.Context) (V, error) {
+	return func(ctx context.Context) (V, error) {
+		t.Helper()
+		t.Error("loader called, want the value read from redis")
+		var zero V
+		return zero, nil
+	}
+}
+
+func TestRedisCacheSetGetDelete(t *testing.T) {
+	server, client := newTestRedis(t)
+	cache := newTestRedisCache(t, client)
+	profiles := NewTypedCache[string, testProfile](cache, "profile")
+	ctx := context.Background()
+
+	want := testProfile{Name: "robson", Followers: 3, Tags: []string{"go"}}
+	profiles.Set("1", want, time.Minute)
+
+	stored := "test:" + profiles.Key("1")
+	if !server.Exists(stored) {
+		t.Fatalf("%s wasn't written, keys held %v", stored, server.Keys())
+	}
+
+	got, err := profiles.GetOrCreate("1", time.Minute, failingLoader[testProfile](t), nil, ctx)
+	if err != nil {
+		t.Fatalf("GetOrCreate: %v", err)
+	}
+	if got.Name != want.Name || got.Followers != want.Followers || len(got.Tags) != 1 || got.Tags[0] != "go" {
+		t.Errorf("read back %+v, want %+v", got, want)
+	}
+
+	raw, err := cache.GetOrCreate(profiles.Key("1"), time.Minute, func(ctx context.Context) (interface{}, error) {
+		t.Error("loader called, want the value read from redis")
+		return nil, nil
+	}, nil, ctx)
+	if err != nil {
+		t.Fatalf("GetOrCreate: %v", err)
+	}
+	if _, ok := raw.(Encoded); !ok {
+		t.Errorf("raw value is %T, want Encoded json", raw)
+	}
+
+	profiles.Delete("1")
+	if server.Exists(stored) {
+		t.Errorf("%s is still held after Delete", stored)
+	}
+
+	loads := 0
+	got, err = profiles.GetOrCreate("1", time.Minute, func(ctx context.Context) (testProfile, error) {
+		loads++
+		return testProfile{Name: "reloaded"}, nil
+	}, nil, ctx)
+	if err != nil {
+		t.Fatalf("GetOrCreate: %v", err)
+	}
+	if loads != 1 || got.Name != "reloaded" {
+		t.Errorf("after Delete read %+v with %d loads, want the reloaded profile from one load", got, loads)
+	}
+}
+
+func TestRedisCacheSharesValuesAcrossInstances(t *testing.T) {
+	_, client := newTestRedis(t)
+	first := newTestRedisCache(t, client)
+	second := newTestRedisCache(t, client)
+	ctx := context.Background()
+
+	want := testProfile{Name: "shared", Followers: 7}
+	if _, err := GetOrCreate(first, "profile:1", time.Minute, func(ctx context.Context) (testProfile, error) {
+		return want, nil
+	}, nil, ctx); err != nil {
+		t.Fatalf("GetOrCreate: %v", err)
+	}
+
+	got, err := GetOrCreate(second, "profile:1", time.Minute, failingLoader[testProfile](t), nil, ctx)
+	if err != nil {
+		t.Fatalf("GetOrCreate: %v", err)
+	}
+	if got.Name != want.Name || got.Followers != want.Followers {
+		t.Errorf("second instance read %+v, want %+v", got, want)
+	}
+}
+
+func TestRedisCacheNegativeEntriesKeepTheirError(t *testing.T) {
+	_, client := newTestRedis(t)
+	cache := newTestRedisCache(t, client)
+	ctx := context.Background()
+
+	_, err := GetOrCreate(cache, "profile:missing", time.Minute, func(ctx context.Context) (testProfile, error) {
+		return testProfile{}, errTestNotFound
+	}, nil, ctx)
+	if !errors.Is(err, errTestNotFound) {
+		t.Fatalf("load returned %v, want errTestNotFound", err)
+	}
+
+	_, err = GetOrCreate(cache, "profile:missing", time.Minute, failingLoader[testProfile](t), nil, ctx)
+	if !errors.Is(err, errTestNotFound) {
+		t.Errorf("cached read returned %v, want errTestNotFound", err)
+	}
+}
+
+func TestRedisCacheInvalidateByTag(t *testing.T) {
+	server, client := newTestRedis(t)
+	cache := newTestRedisCache(t, client)
+
+	cache.Set("profile:1", testProfile{Name: "one"}, time.Minute, "user:1")
+	cache.Set("exists:1", true, time.Minute, "user:1")
+	cache.Set("profile:2", testProfile{Name: "two"}, time.Minute, "user:2")
+
+	members, err := server.SMembers("test:tag:user:1")
+	if err != nil || len(members) != 2 {
+		t.Fatalf("tag user:1 holds %v (%v), want both of its keys", members, err)
+	}
+	if ttl := server.TTL("test:tag:user:1"); ttl <= 0 {
+		t.Errorf("tag user:1 has ttl %s, want it to expire with its entries", ttl)
+	}
+
+	if removed := cache.Invalidate("user:1"); removed != 2 {
+		t.Errorf("Invalidate removed %d entries, want 2", removed)
+	}
+
+	for _, key := range []string{"test:profile:1", "test:exists:1", "test:tag:user:1"} {
+		if server.Exists(key) {
+			t.Errorf("%s is still held after invalidating user:1", key)
+		}
+	}
+	if !server.Exists("test:profile:2") {
+		t.Error("test:profile:2 was removed by invalidating user:1")
+	}
+}
+
+func TestRedisCacheTagOutlivesItsLongestEntry(t *testing.T) {
+	server, client := newTestRedis(t)
+	cache := newTestRedisCache(t, client)
+
+	cache.Set("profile:1", testProfile{Name: "one"}, time.Hour, "user:1")
+	cache.Set("exists:1", true, time.Minute, "user:1")
+
+	if ttl := server.TTL("test:tag:user:1"); ttl < 59*time.Minute {
+		t.Errorf("tag user:1 has ttl %s, a shorter entry must not cut it below the hour its longest entry lives", ttl)
+	}
+}
+
+func TestCheckRedisVersion(t *testing.T) {
+	_, client := newTestRedis(t)
+	if err := CheckRedisVersion(client, context.Background()); err != nil {
+		t.Errorf("CheckRedisVersion: %v", err)
+	}
+
+	tests := []struct {
+		reply interface{}
+		want  string
+	}{
+		{map[interface{}]interface{}{"server": "redis", "version": "7.2.4"}, "7.2.4"},
+		{[]interface{}{"server", "redis", "version", "6.2.14"}, "6.2.14"},
+		{"unexpected", ""},
+	}
+	for _, test := range tests {
+		if got := helloField(test.reply, "version"); got != test.want {
+			t.Errorf("helloField(%v) = %q, want %q", test.reply, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package caching
+
+import "reflect"
+
+// entryOverhead roughly covers the map slot, list element and bookkeeping held for every entry.
+const entryOverhead = 128
+
+// approximateSize estimates the bytes held by an entry, it is only meant to keep MaxBytes in the right ballpark.
+func approximateSize(key string, value interface{}) int64 {
+	sizer := sizer{visited: map[reference]bool{}}
+	return entryOverhead + int64(len(key)) + sizer.sizeOf(reflect.ValueOf(value))
+}
+
+// reference identifies memory reached through a pointer, map or slice, the type tells apart a struct from
+// its first field which share an address.
+type reference struct {
+	address uintptr
+	typ     reflect.Type
+}
+
+// sizer walks a value counting what each reference points at once, so shared and cyclic values terminate.
+type sizer struct {
+	visited map[reference]bool
+}
+
+// seen records value's reference and reports whether it was already counted.
+func (s sizer) seen(value reflect.Value) bool {
+	ref := reference{address: value.Pointer(), typ: value.Type()}
+	if s.visited[ref] {
+		return true
+	}
+	s.visited[ref] = true
+	return false
+}
+
+func (s sizer) sizeOf(value reflect.Value) int64 {
+	if !value.IsValid() {
+		return 0
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return int64(value.Type().Size()) + int64(value.Len())
+	case reflect.Slice:
+		size := int64(value.Type().Size())
+		if value.Len() == 0 || s.seen(value) {
+			return size
+		}
+		for i := 0; i < value.Len(); i++ {
+			size += s.sizeOf(value.Index(i))
+		}
+		return size
+	case reflect.Array:
+		if value.Type().Elem().Kind() <= reflect.Complex128 {
+			return int64(value.Type().Size())
+		}
+		size := int64(0)
+		for i := 0; i < value.Len(); i++ {
+			size += s.sizeOf(value.Index(i))
+		}
+		return size
+	case reflect.Map:
+		size := int64(value.Type().Size())
+		if value.IsNil() || s.seen(value) {
+			return size
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			size += s.sizeOf(iter.Key()) + s.sizeOf(iter.Value())
+		}
+		return size
+	case reflect.Struct:
+		size := int64(0)
+		for i := 0; i < value.NumField(); i++ {
+			size += s.sizeOf(value.Field(i))
+		}
+		return size
+	case reflect.Pointer:
+		if value.IsNil() || s.seen(value) {
+			return int64(value.Type().Size())
+		}
+		return int64(value.Type().Size()) + s.sizeOf(value.Elem())
+	case reflect.Interface:
+		if value.IsNil() {
+			return int64(value.Type().Size())
+		}
+		return int64(value.Type().Size()) + s.sizeOf(value.Elem())
+	default:
+		return int64(value.Type().Size())
+	}
+}
@@ -0,0 +1,34 @@
+package caching
+
+import "testing"
+
+type node struct {
+	Name string
+	Next *node
+}
+
+func TestApproximateSizeStopsAtCycles(t *testing.T) {
+	first := &node{Name: "first"}
+	second := &node{Name: "second", Next: first}
+	first.Next = second
+
+	looped := []interface{}{nil}
+	looped[0] = looped
+
+	for name, value := range map[string]interface{}{"pointer cycle": first, "slice holding itself": looped} {
+		if size := approximateSize("key", value); size <= entryOverhead {
+			t.Errorf("%s: size %d doesn't count the value", name, size)
+		}
+	}
+}
+
+func TestApproximateSizeCountsSharedValuesOnce(t *testing.T) {
+	shared := &node{Name: "shared"}
+	once := approximateSize("key", []*node{shared})
+	twice := approximateSize("key", []*node{shared, shared})
+
+	pointerSize := approximateSize("key", []*node{nil, nil}) - approximateSize("key", []*node{nil})
+	if twice-once != pointerSize {
+		t.Errorf("second reference added %d bytes, want only the %d byte pointer", twice-once, pointerSize)
+	}
+}
@@ -0,0 +1,64 @@
+package caching
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a point in time read of the cache counters.
+type Stats struct {
+	// Hits and Misses count reads answered from the cache and reads that had to load.
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// Evictions counts entries dropped to stay within MaxEntries or MaxBytes.
+	Evictions uint64 `json:"evictions"`
+	// Loads and LoadFailures count calls to a loader, LoadDuration is the time spent in all of them.
+	Loads        uint64        `json:"loads"`
+	LoadFailures uint64        `json:"load_failures"`
+	LoadDuration time.Duration `json:"load_duration_ns"`
+	// Shared counts reads that waited on a load another caller had already started.
+	Shared uint64 `json:"shared"`
+	// StaleServes counts reads answered with an expired value while it was being refreshed.
+	StaleServes uint64 `json:"stale_serves"`
+	// Refreshes and RefreshFailures count background reloads of stale values and how many of them failed.
+	Refreshes       uint64 `json:"refreshes"`
+	RefreshFailures uint64 `json:"refresh_failures"`
+}
+
+// HitRatio is the share of reads answered without loading, stale serves count as hits.
+func (s Stats) HitRatio() float64 {
+	hits := s.Hits + s.StaleServes
+	if hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+s.Misses)
+}
+
+type counters struct {
+	hits            atomic.Uint64
+	misses          atomic.Uint64
+	evictions       atomic.Uint64
+	loads           atomic.Uint64
+	loadFailures    atomic.Uint64
+	loadNanos       atomic.Int64
+	shared          atomic.Uint64
+	staleServes     atomic.Uint64
+	refreshes       atomic.Uint64
+	refreshFailures atomic.Uint64
+}
+
+// Stats returns the current counter values.
+func (e *engine) Stats() Stats {
+	return Stats{
+		Hits:            e.stats.hits.Load(),
+		Misses:          e.stats.misses.Load(),
+		Evictions:       e.stats.evictions.Load(),
+		Loads:           e.stats.loads.Load(),
+		LoadFailures:    e.stats.loadFailures.Load(),
+		LoadDuration:    time.Duration(e.stats.loadNanos.Load()),
+		Shared:          e.stats.shared.Load(),
+		StaleServes:     e.stats.staleServes.Load(),
+		Refreshes:       e.stats.refreshes.Load(),
+		RefreshFailures: e.stats.refreshFailures.Load(),
+	}
+}
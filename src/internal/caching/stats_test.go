@@ -0,0 +1,65 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatsCountReadsAndLoads(t *testing.T) {
+	cache := NewMemoryCache(Options{})
+
+	calls := 0
+	read := func(key string, err error) {
+		cache.GetOrCreate(key, time.Minute, countingLoader(&calls, err), nil, context.Background())
+	}
+
+	read("a", nil)
+	read("a", nil)
+	read("a", nil)
+	read("b", errors.New("down"))
+
+	stats := cache.Stats()
+	want := Stats{Hits: 2, Misses: 2, Loads: 2, LoadFailures: 1}
+	stats.LoadDuration = 0
+	if stats != want {
+		t.Errorf("Stats() = %+v, want %+v", stats, want)
+	}
+
+	if ratio := stats.HitRatio(); ratio != 0.5 {
+		t.Errorf("HitRatio() = %v, want 0.5", ratio)
+	}
+}
+
+func TestStatsCountLoadDuration(t *testing.T) {
+	cache := NewMemoryCache(Options{})
+	cache.GetOrCreate("key", time.Minute, func(ctx context.Context) (interface{}, error) {
+		time.Sleep(5 * time.Millisecond)
+		return "value", nil
+	}, nil, context.Background())
+
+	if duration := cache.Stats().LoadDuration; duration < 5*time.Millisecond {
+		t.Errorf("LoadDuration = %v, want at least 5ms", duration)
+	}
+}
+
+func TestHitRatioCountsStaleServesAsHits(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats Stats
+		want  float64
+	}{
+		{"no reads", Stats{}, 0},
+		{"only misses", Stats{Misses: 4}, 0},
+		{"stale serves", Stats{Hits: 1, StaleServes: 2, Misses: 1}, 0.75},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if ratio := test.stats.HitRatio(); ratio != test.want {
+				t.Errorf("HitRatio() = %v, want %v", ratio, test.want)
+			}
+		})
+	}
+}
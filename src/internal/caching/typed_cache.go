@@ -0,0 +1,70 @@
+package caching
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TypedCache is a namespaced view over a Cache holding values of a single type, keys are stored as "<namespace>-<key>".
+type TypedCache[K comparable, V any] struct {
+	cache     Cache
+	namespace string
+}
+
+func NewTypedCache[K comparable, V any](cache Cache, namespace string) *TypedCache[K, V] {
+	return &TypedCache[K, V]{
+		cache:     cache,
+		namespace: namespace,
+	}
+}
+
+func (t *TypedCache[K, V]) Key(key K) string {
+	return fmt.Sprintf("%s-%v", t.namespace, key)
+}
+
+func (t *TypedCache[K, V]) GetOrCreate(key K, expiration time.Duration, createFn func(ctx context.Context) (V, error), tags []string, ctx context.Context) (V, error) {
+	return GetOrCreate(t.cache, t.Key(key), expiration, createFn, tags, ctx)
+}
+
+func (t *TypedCache[K, V]) Set(key K, value V, expiration time.Duration, tags ...string) {
+	t.cache.Set(t.Key(key), value, expiration, tags...)
+}
+
+func (t *TypedCache[K, V]) Delete(key K) {
+	t.cache.Delete(t.Key(key))
+}
+
+// Flush removes every entry in the namespace.
+func (t *TypedCache[K, V]) Flush() int {
+	return t.cache.DeletePrefix(t.namespace + "-")
+}
+
+// Encoded is a json value read back from a shared backend, GetOrCreate decodes it into the type asked for.
+type Encoded []byte
+
+// GetOrCreate is the typed form of Cache.GetOrCreate, it fails if key already holds a value of another type.
+func GetOrCreate[V any](cache Cache, key string, expiration time.Duration, createFn func(ctx context.Context) (V, error), tags []string, ctx context.Context) (V, error) {
+	var zero V
+
+	value, err := cache.GetOrCreate(key, expiration, func(ctx context.Context) (interface{}, error) {
+		return createFn(ctx)
+	}, tags, ctx)
+	if err != nil {
+		return zero, err
+	}
+
+	switch typed := value.(type) {
+	case V:
+		return typed, nil
+	case Encoded:
+		var decoded V
+		if err := json.Unmarshal(typed, &decoded); err != nil {
+			return zero, fmt.Errorf("cache entry %s can't be decoded as %T: %w", key, zero, err)
+		}
+		return decoded, nil
+	default:
+		return zero, fmt.Errorf("cache entry %s holds %T, expected %T", key, value, zero)
+	}
+}
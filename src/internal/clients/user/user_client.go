@@ -3,35 +3,43 @@ package userClient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"sync"
-	"time"
 
 	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
 	responses "github.com/RobsonDevCode/go-profile-service/src/internal/clients/user/responses"
 	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
 	"github.com/google/uuid"
 	"github.com/sony/gobreaker"
+	"go.uber.org/zap"
 )
 
+// ErrUserNotFound is returned when the user service has no user for the id, it is safe to cache.
+var ErrUserNotFound = errors.New("user not found")
+
 type UserClient struct {
-	client  *http.Client
-	cb      *gobreaker.CircuitBreaker
-	baseUrl *url.URL
-	jwt     string
-	jwtLock sync.RWMutex
-	cache   *caching.Cache
+	client   *http.Client
+	cb       *gobreaker.CircuitBreaker
+	baseUrl  *url.URL
+	jwt      string
+	jwtLock  sync.RWMutex
+	users    *caching.TypedCache[uuid.UUID, User]
+	settings *config.Store
 }
 
-func NewUserClient(config config.Config, cache *caching.Cache) (*UserClient, error) {
+// NewUserClient reads the cache ttl and circuit breaker threshold from settings on each use, so both follow
+// config reloads.
+func NewUserClient(settings *config.Store, cache caching.Cache, logger *zap.Logger) (*UserClient, error) {
+	current := settings.Get()
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout: current.Timeouts.UserClient,
 		Transport: &http.Transport{
 			MaxIdleConns:        100,
 			MaxIdleConnsPerHost: 20,
-			IdleConnTimeout:     90 * time.Second,
+			IdleConnTimeout:     current.Timeouts.UserClientIdle,
 		},
 	}
 
@@ -39,26 +47,31 @@ func NewUserClient(config config.Config, cache *caching.Cache) (*UserClient, err
 		Name:        "user-client",
 		MaxRequests: 0,
 		Interval:    0,
-		Timeout:     10 * time.Second,
+		Timeout:     current.UserClientOptions.CircuitBreaker.OpenTimeout,
 		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			return counts.ConsecutiveFailures >= 5
+			return counts.ConsecutiveFailures >= uint32(settings.Get().UserClientOptions.CircuitBreaker.FailureThreshold)
+		},
+		IsSuccessful: func(err error) bool {
+			return err == nil || errors.Is(err, ErrUserNotFound)
 		},
 		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
-			fmt.Printf("Circuit breaker state changed from %v to %v\n", from, to)
+			logger.Warn("circuit breaker state changed",
+				zap.String("breaker", name), zap.Stringer("from", from), zap.Stringer("to", to))
 		},
 	}
 
 	cb := gobreaker.NewCircuitBreaker(cbSettings)
-	baseUrl, err := url.Parse(config.UserClientOptions.URL)
+	baseUrl, err := url.Parse(current.UserClientOptions.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid base url: %w", err)
 	}
 
 	return &UserClient{
-		client:  client,
-		baseUrl: baseUrl,
-		cb:      cb,
-		cache:   cache,
+		client:   client,
+		baseUrl:  baseUrl,
+		cb:       cb,
+		users:    caching.NewTypedCache[uuid.UUID, User](cache, "user"),
+		settings: settings,
 	}, nil
 }
 
@@ -72,9 +85,7 @@ func (c *UserClient) SetJwt(token string) {
 func (c *UserClient) Get(id uuid.UUID, ctx context.Context) (User, error) {
 	url := fmt.Sprintf("%s/%s", c.baseUrl, id)
 
-	key := fmt.Sprintf("user-%v", id)
-
-	result, err := c.cache.GetOrCreate(key, time.Minute*5, func() (interface{}, error) {
+	return c.users.GetOrCreate(id, c.settings.Get().Cache.TTL.User, func(ctx context.Context) (User, error) {
 		result, err := c.cb.Execute(func() (interface{}, error) {
 			request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 			if err != nil {
@@ -87,7 +98,9 @@ func (c *UserClient) Get(id uuid.UUID, ctx context.Context) (User, error) {
 			}
 			defer response.Body.Close()
 
-			if response.StatusCode >= 500 {
+			if response.StatusCode == http.StatusNotFound {
+				return User{}, fmt.Errorf("user %s: %w", id, ErrUserNotFound)
+			} else if response.StatusCode >= 500 {
 				return User{}, fmt.Errorf("server error on user client: %d", response.StatusCode)
 			} else if response.StatusCode == 400 {
 				var problemDetails responses.ProblemDetails
@@ -114,25 +127,22 @@ func (c *UserClient) Get(id uuid.UUID, ctx context.Context) (User, error) {
 			return User{}, fmt.Errorf("circuit breaker error: %w", err)
 		}
 
-		return result, nil
-	})
-
-	if err != nil {
-		return User{}, err
-	}
-
-	user, ok := result.(User)
-	if !ok {
-		return User{}, fmt.Errorf("unexpected response type")
-	}
+		user, ok := result.(User)
+		if !ok {
+			return User{}, fmt.Errorf("unexpected response type")
+		}
 
-	return user, nil
+		return user, nil
+	}, []string{caching.UserTag(id)}, ctx)
 }
 
 func (c *UserClient) UserExists(id uuid.UUID, ctx context.Context) (bool, error) {
 
 	user, err := c.Get(id, ctx)
 	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return false, nil
+		}
 		return false, err
 	}
 
@@ -1,18 +1,58 @@
+// Package config loads the service configuration. Settings are layered, each source overriding the ones
+// before it:
+//
+//  1. defaults, see Default
+//  2. the yaml config file, taken from the -config flag, else PROFILE_SERVICE_CONFIG, else DefaultPath
+//  3. environment variables named PROFILE_<SECTION>_<SETTING>, such as PROFILE_DATABASE_PASSWORD or
+//     PROFILE_CACHE_MAX_ENTRIES, nested sections add a segment: PROFILE_CACHE_REDIS_ADDR
+//  4. -set flags naming the yaml path of a setting, such as -set database.port=3307
+//
+// Any setting can be read from a file instead, which is how mounted secrets are passed in: add a _file suffix
+// to its yaml key (password_file: /run/secrets/db), environment variable (PROFILE_DATABASE_PASSWORD_FILE) or
+// -set name (-set database.password_file=/run/secrets/db). Yaml values may also embed ${file:/path} and
+// ${env:NAME} references. Passwords and keys are held as Secret so they are redacted whenever config is logged.
+//
+// Settings tagged reload:"live" are read from the Store on every use, a Reloader swaps in a new snapshot when
+// the file changes or the process receives SIGHUP. Every other setting is read once at start up.
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-const FilePath = "C:/Users/RobsonBasquill-Lipsc/Repos/Test/go-api/src/config/config.yaml"
+// DefaultPath is read when no config path is given, it is skipped if it doesn't exist.
+const DefaultPath = "src/config/config.yaml"
+
+// PathEnv names the environment variable holding the config file path.
+const PathEnv = "PROFILE_SERVICE_CONFIG"
 
 type Config struct {
-	Database          DBConfig
-	UserClientOptions UserClient
-	JWTSettings       JWTSettings
+	Server            ServerSettings     `yaml:"server"`
+	Database          DBConfig           `yaml:"database"`
+	Pool              PoolSettings       `yaml:"pool"`
+	Timeouts          TimeoutSettings    `yaml:"timeouts"`
+	UserClientOptions UserClient         `yaml:"userclientoptions"`
+	JWTSettings       JWTSettings        `yaml:"jwtsettings"`
+	Pagination        PaginationSettings `yaml:"pagination"`
+	Cache             CacheSettings      `yaml:"cache"`
+	Log               LogSettings        `yaml:"log"`
+}
+
+type ServerSettings struct {
+	// Addr is where the http server listens, ":8080" by default.
+	Addr string `yaml:"addr"`
+	// MetricsAddr is where prometheus metrics are served, apart from the api so it needn't be public, ":9090" by default.
+	MetricsAddr string `yaml:"metricsAddr"`
+	// ShutdownTimeout is how long in flight requests get to finish on shutdown, ten seconds by default.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
 }
 
 type DBConfig struct {
@@ -21,13 +61,39 @@ type DBConfig struct {
 	Port              int    `yaml:"port"`
 	Name              string `yaml:"name"`
 	Username          string `yaml:"username"`
-	Password          string `yaml:"password"`
+	Password          Secret `yaml:"password"`
 	SSlMode           string `yaml:"sslMode"`
 	AllowZeroDateTime bool   `yaml:"allowZeroDateTime"`
 }
 
+// PoolSettings size the database connection pool, zero max open connections leaves it unlimited.
+type PoolSettings struct {
+	MaxOpenConns    int           `yaml:"maxOpenConns"`
+	MaxIdleConns    int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime"`
+}
+
+type TimeoutSettings struct {
+	// List bounds requests returning a page of results, a minute by default.
+	List time.Duration `yaml:"list" reload:"live"`
+	// Request bounds every other request, three seconds by default.
+	Request time.Duration `yaml:"request" reload:"live"`
+	// UserClient bounds each call to the user service, ten seconds by default.
+	UserClient time.Duration `yaml:"userClient"`
+	// UserClientIdle is how long an idle connection to the user service is kept, ninety seconds by default.
+	UserClientIdle time.Duration `yaml:"userClientIdle"`
+}
+
 type UserClient struct {
-	URL string `yaml:"baseUrl"`
+	URL            string                 `yaml:"baseUrl"`
+	CircuitBreaker CircuitBreakerSettings `yaml:"circuitBreaker"`
+}
+
+type CircuitBreakerSettings struct {
+	// FailureThreshold is how many consecutive failures open the breaker, five by default.
+	FailureThreshold int `yaml:"failureThreshold" reload:"live"`
+	// OpenTimeout is how long the breaker stays open before letting a request through, ten seconds by default.
+	OpenTimeout time.Duration `yaml:"openTimeout"`
 }
 
 type JWTSettings struct {
@@ -35,38 +101,246 @@ type JWTSettings struct {
 	Audience              string `yaml:"audience"`
 	ExpiresInMinutes      int    `yaml:"expiresInMinutes"`
 	RefreshTokenExpiresIn int    `yaml:"refreshTokenExpiresIn"`
-	Key                   string `yaml:"key"`
+	Key                   Secret `yaml:"key"`
+}
+
+type PaginationSettings struct {
+	// CursorKey signs pagination cursors, it must differ from the jwt key.
+	CursorKey Secret `yaml:"cursorKey"`
+	// DefaultSize and MaxSize bound page sizes and cursor limits, a hundred and 250 by default.
+	DefaultSize int `yaml:"defaultSize" reload:"live"`
+	MaxSize     int `yaml:"maxSize" reload:"live"`
+}
+
+type CacheSettings struct {
+	// MaxEntries and MaxBytes bound the in-memory cache, zero leaves the limit off.
+	MaxEntries int   `yaml:"maxEntries"`
+	MaxBytes   int64 `yaml:"maxBytes"`
+	// CleanupInterval is how often expired entries are swept, a minute by default.
+	CleanupInterval time.Duration `yaml:"cleanupInterval"`
+	// NegativeTTL is how long not found results are cached, thirty seconds by default.
+	NegativeTTL time.Duration `yaml:"negativeTtl"`
+	// StaleTTL lets expired values be served while they refresh in the background, zero turns it off.
+	StaleTTL time.Duration `yaml:"staleTtl"`
+	// Jitter spreads ttls by up to this fraction either way, zero turns it off.
+	Jitter float64 `yaml:"jitter"`
+	// Backend is where entries live: "memory" per replica (the default), "redis" shared by every replica,
+	// or "near" for a local copy of the redis cache kept in sync over pub/sub.
+	Backend string         `yaml:"backend"`
+	Redis   RedisSettings  `yaml:"redis"`
+	Warmup  WarmupSettings `yaml:"warmup"`
+	TTL     CacheTTLs      `yaml:"ttl" reload:"live"`
+}
+
+// CacheTTLs are how long each kind of entry is cached for.
+type CacheTTLs struct {
+	Profile      time.Duration `yaml:"profile"`
+	Exists       time.Duration `yaml:"exists"`
+	Relationship time.Duration `yaml:"relationship"`
+	User         time.Duration `yaml:"user"`
+}
+
+type LogSettings struct {
+	// Level is the minimum level logged, such as debug, info or warn, info by default.
+	Level string `yaml:"level" reload:"live"`
+}
+
+// WarmupSettings control loading the most followed profiles into the cache before the service reports ready.
+type WarmupSettings struct {
+	Enabled bool `yaml:"enabled"`
+	// Count is how many profiles are loaded, a thousand by default.
+	Count int `yaml:"count"`
+	// Budget caps how long warmup may hold back readiness, thirty seconds by default.
+	Budget time.Duration `yaml:"budget"`
+}
+
+type RedisSettings struct {
+	Addr     string `yaml:"addr"`
+	Password Secret `yaml:"password"`
+	DB       int    `yaml:"db"`
+	// KeyPrefix namespaces every key written, "profile-service:" by default.
+	KeyPrefix string `yaml:"keyPrefix"`
+	// Channel carries near cache invalidations between replicas, "profile-service:invalidations" by default.
+	Channel string `yaml:"channel"`
+}
+
+// Default is the configuration before any file, environment variable or flag is applied.
+func Default() Config {
+	return Config{
+		Server: ServerSettings{
+			Addr:            ":8080",
+			MetricsAddr:     ":9090",
+			ShutdownTimeout: 10 * time.Second,
+		},
+		Database: DBConfig{
+			Driver: "mysql",
+			Port:   3306,
+		},
+		Pool: PoolSettings{
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+		Timeouts: TimeoutSettings{
+			List:           time.Minute,
+			Request:        3 * time.Second,
+			UserClient:     10 * time.Second,
+			UserClientIdle: 90 * time.Second,
+		},
+		UserClientOptions: UserClient{
+			CircuitBreaker: CircuitBreakerSettings{
+				FailureThreshold: 5,
+				OpenTimeout:      10 * time.Second,
+			},
+		},
+		Pagination: PaginationSettings{
+			DefaultSize: 100,
+			MaxSize:     250,
+		},
+		Log: LogSettings{
+			Level: "info",
+		},
+		Cache: CacheSettings{
+			CleanupInterval: time.Minute,
+			NegativeTTL:     30 * time.Second,
+			Backend:         "memory",
+			Redis: RedisSettings{
+				KeyPrefix: "profile-service:",
+				Channel:   "profile-service:invalidations",
+			},
+			Warmup: WarmupSettings{
+				Count:  1000,
+				Budget: 30 * time.Second,
+			},
+			TTL: CacheTTLs{
+				Profile:      3 * time.Minute,
+				Exists:       5 * time.Minute,
+				Relationship: time.Minute,
+				User:         5 * time.Minute,
+			},
+		},
+	}
+}
+
+// Load builds the config from the defaults, config file, environment and the command line args, in that order
+// of precedence, then validates it.
+func Load(args []string) (*Config, error) {
+	config, err := build(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return config, nil
+}
+
+// commandLine is what the args say about where config comes from.
+type commandLine struct {
+	path      string
+	overrides []string
 }
 
-func Load() (*Config, error) {
+func parseArgs(args []string) (commandLine, error) {
+	flags := flag.NewFlagSet("profile-service", flag.ContinueOnError)
+	path := flags.String("config", "", "path to the yaml config file, overrides "+PathEnv)
+	var overrides stringList
+	flags.Var(&overrides, "set", "override a setting by its yaml path, such as -set database.port=3307, may be repeated")
+	if err := flags.Parse(args); err != nil {
+		return commandLine{}, err
+	}
 
-	data, err := os.ReadFile(FilePath)
+	return commandLine{path: *path, overrides: overrides}, nil
+}
+
+// build layers every source without validating the result.
+func build(args []string) (*Config, error) {
+	commandLine, err := parseArgs(args)
 	if err != nil {
 		return nil, err
 	}
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("error parsing config file: %w", err)
+
+	config := Default()
+
+	if err := readFile(&config, commandLine.path); err != nil {
+		return nil, err
 	}
 
-	if err := validateConfig(&config); err != nil {
-		return nil, fmt.Errorf("invalid configL %w", err)
+	if err := applyEnv(&config, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := applyFlags(&config, commandLine.overrides); err != nil {
+		return nil, err
 	}
 
 	return &config, nil
 }
 
-func validateConfig(config *Config) error {
-	if config.Database.Username == "" {
-		return fmt.Errorf("database username is required")
+// resolvePath picks the config file path, optional is set when falling back to DefaultPath.
+func resolvePath(path string) (resolved string, optional bool) {
+	if path == "" {
+		path = os.Getenv(PathEnv)
+	}
+
+	if path == "" {
+		return DefaultPath, true
 	}
-	if config.Database.Name == "" {
-		return fmt.Errorf("database name is required")
+	return path, false
+}
+
+// readFile unmarshals the config file over config. A path given by flag or environment must exist,
+// DefaultPath is optional so the service can run from environment variables alone.
+func readFile(config *Config, path string) error {
+	path, optional := resolvePath(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("error reading config file: %w", err)
 	}
 
-	if config.Database.Port <= 0 {
-		return fmt.Errorf("server port must be positive")
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("error parsing config file %s: %w", path, err)
 	}
 
+	if root.Kind == 0 {
+		return nil
+	}
+
+	if err := resolveReferences(&root, os.LookupEnv); err != nil {
+		return fmt.Errorf("error resolving references in config file %s: %w", path, err)
+	}
+
+	if err := root.Decode(config); err != nil {
+		return fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// String dumps the config as yaml with every secret redacted, so it is safe to log.
+func (c Config) String() string {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Sprintf("unprintable config: %v", err)
+	}
+	return string(data)
+}
+
+// stringList collects a repeated flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
 	return nil
 }
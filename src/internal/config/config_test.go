@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFile writes contents to name in a temporary directory and returns its path.
+func writeFile(t *testing.T, name string, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+	return path
+}
+
+// envFrom looks variables up in env rather than the process environment.
+func envFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+// layer builds config the way build does, with env standing in for the process environment.
+func layer(t *testing.T, file string, env map[string]string, overrides ...string) (*Config, error) {
+	t.Helper()
+
+	config := Default()
+	if file != "" {
+		if err := readFile(&config, writeFile(t, "config.yaml", file)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(&config, envFrom(env)); err != nil {
+		return nil, err
+	}
+
+	if err := applyFlags(&config, overrides); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func TestLayerPrecedence(t *testing.T) {
+	const file = "database:\n  port: 3307\n"
+	env := map[string]string{"PROFILE_DATABASE_PORT": "3308"}
+
+	tests := []struct {
+		name      string
+		file      string
+		env       map[string]string
+		overrides []string
+		want      int
+	}{
+		{"defaults", "", nil, nil, 3306},
+		{"file over defaults", file, nil, nil, 3307},
+		{"env over file", file, env, nil, 3308},
+		{"set over env", file, env, []string{"database.port=3309"}, 3309},
+		{"last set wins", file, env, []string{"database.port=3309", "database.port=3310"}, 3310},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := layer(t, test.file, test.env, test.overrides...)
+			if err != nil {
+				t.Fatalf("layering config: %v", err)
+			}
+
+			if config.Database.Port != test.want {
+				t.Errorf("database.port = %d, want %d", config.Database.Port, test.want)
+			}
+		})
+	}
+}
+
+func TestLayersLeaveOtherSettingsAlone(t *testing.T) {
+	config, err := layer(t, "database:\n  host: db\n", map[string]string{"PROFILE_DATABASE_PORT": "3308"})
+	if err != nil {
+		t.Fatalf("layering config: %v", err)
+	}
+
+	if config.Database.Host != "db" || config.Database.Driver != "mysql" || config.Pool.MaxOpenConns != 25 {
+		t.Errorf("config = %+v, want the file host over the default driver and pool", config)
+	}
+}
+
+func TestEnvKey(t *testing.T) {
+	want := map[string]string{
+		"database.password":      "PROFILE_DATABASE_PASSWORD",
+		"server.metricsAddr":     "PROFILE_SERVER_METRICS_ADDR",
+		"cache.maxEntries":       "PROFILE_CACHE_MAX_ENTRIES",
+		"cache.redis.addr":       "PROFILE_CACHE_REDIS_ADDR",
+		"cache.ttl.relationship": "PROFILE_CACHE_TTL_RELATIONSHIP",
+		"jwtsettings.key":        "PROFILE_JWTSETTINGS_KEY",
+		"pagination.cursorKey":   "PROFILE_PAGINATION_CURSOR_KEY",
+		"userclientoptions.circuitBreaker.failureThreshold": "PROFILE_USERCLIENTOPTIONS_CIRCUIT_BREAKER_FAILURE_THRESHOLD",
+	}
+
+	found := make(map[string]string)
+	for _, setting := range settings(&Config{}) {
+		found[setting.Name()] = setting.EnvKey()
+	}
+
+	for name, key := range want {
+		if found[name] != key {
+			t.Errorf("%s EnvKey() = %q, want %q", name, found[name], key)
+		}
+	}
+}
+
+func TestScreamingSnake(t *testing.T) {
+	tests := map[string]string{
+		"port":        "PORT",
+		"maxEntries":  "MAX_ENTRIES",
+		"negativeTtl": "NEGATIVE_TTL",
+		"jwtsettings": "JWTSETTINGS",
+		"db":          "DB",
+	}
+
+	for name, want := range tests {
+		if got := screamingSnake(name); got != want {
+			t.Errorf("screamingSnake(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestEnvFileIsRead(t *testing.T) {
+	secret := writeFile(t, "password", "hunter2\n")
+
+	config, err := layer(t, "", map[string]string{"PROFILE_DATABASE_PASSWORD_FILE": secret})
+	if err != nil {
+		t.Fatalf("layering config: %v", err)
+	}
+
+	if config.Database.Password.Value() != "hunter2" {
+		t.Errorf("database.password = %q, want the file contents without the newline", config.Database.Password.Value())
+	}
+}
+
+func TestEnvAndEnvFileBothSet(t *testing.T) {
+	secret := writeFile(t, "password", "hunter2")
+
+	_, err := layer(t, "", map[string]string{
+		"PROFILE_DATABASE_PASSWORD":      "direct",
+		"PROFILE_DATABASE_PASSWORD_FILE": secret,
+	})
+	if err == nil || !strings.Contains(err.Error(), "are both set") {
+		t.Errorf("err = %v, want both variables being set reported", err)
+	}
+}
+
+func TestSetFileFlagReadsFile(t *testing.T) {
+	secret := writeFile(t, "password", "hunter2\n")
+
+	config, err := layer(t, "", nil, "database.password_file="+secret)
+	if err != nil {
+		t.Fatalf("layering config: %v", err)
+	}
+
+	if config.Database.Password.Value() != "hunter2" {
+		t.Errorf("database.password = %q, want the file contents without the newline", config.Database.Password.Value())
+	}
+}
+
+func TestSetFlagErrors(t *testing.T) {
+	tests := map[string]string{
+		"no value":      "database.port",
+		"unknown":       "database.nope=1",
+		"wrong type":    "database.port=many",
+		"missing file":  "database.password_file=" + filepath.Join(t.TempDir(), "missing"),
+		"unknown _file": "database.nope_file=" + writeFile(t, "value", "1"),
+		"not a float":   "cache.jitter=lots",
+	}
+
+	for name, override := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := layer(t, "", nil, override); err == nil {
+				t.Errorf("-set %s was accepted", override)
+			}
+		})
+	}
+}
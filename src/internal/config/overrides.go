@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const envPrefix = "PROFILE"
+
+// setting is one leaf field of Config, addressed by the yaml names on the way down to it.
+// Live settings, tagged reload:"live" directly or through their section, take effect on reload.
+type setting struct {
+	path  []string
+	value reflect.Value
+	live  bool
+}
+
+// Name is the dotted path used by the -set flag, such as database.password.
+func (s setting) Name() string {
+	return strings.Join(s.path, ".")
+}
+
+// EnvKey is the environment variable overriding the setting, such as PROFILE_DATABASE_PASSWORD.
+func (s setting) EnvKey() string {
+	parts := []string{envPrefix}
+	for _, segment := range s.path {
+		parts = append(parts, screamingSnake(segment))
+	}
+	return strings.Join(parts, "_")
+}
+
+// settings walks every leaf field of config.
+func settings(config *Config) []setting {
+	var found []setting
+	collect(reflect.ValueOf(config).Elem(), nil, false, &found)
+	return found
+}
+
+func collect(value reflect.Value, path []string, live bool, found *[]setting) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := append(append([]string{}, path...), yamlName(field))
+		fieldLive := live || field.Tag.Get("reload") == "live"
+		if field.Type.Kind() == reflect.Struct {
+			collect(value.Field(i), fieldPath, fieldLive, found)
+			continue
+		}
+
+		*found = append(*found, setting{path: fieldPath, value: value.Field(i), live: fieldLive})
+	}
+}
+
+// yamlName is the key yaml.v3 reads the field from, the lowercased field name when it has no tag.
+func yamlName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); name != "" {
+		return name
+	}
+	return strings.ToLower(field.Name)
+}
+
+// screamingSnake turns a yaml key such as maxEntries into MAX_ENTRIES.
+func screamingSnake(name string) string {
+	var out strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			out.WriteRune('_')
+		}
+		out.WriteRune(unicode.ToUpper(r))
+	}
+	return out.String()
+}
+
+// set parses raw into the setting's type.
+func (s setting) set(raw string) error {
+	switch s.value.Interface().(type) {
+	case string, Secret:
+		s.value.SetString(raw)
+	case time.Duration:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Name(), err)
+		}
+		s.value.SetInt(int64(parsed))
+	case int, int64:
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Name(), err)
+		}
+		s.value.SetInt(parsed)
+	case bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Name(), err)
+		}
+		s.value.SetBool(parsed)
+	case float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Name(), err)
+		}
+		s.value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("%s: unsupported setting type %s", s.Name(), s.value.Type())
+	}
+
+	return nil
+}
+
+// applyEnv overrides every setting that has its environment variable set, or its _FILE variable naming a file
+// to read the value from.
+func applyEnv(config *Config, lookupEnv func(string) (string, bool)) error {
+	for _, setting := range settings(config) {
+		key := setting.EnvKey()
+		raw, ok := lookupEnv(key)
+
+		fileKey := key + strings.ToUpper(fileSuffix)
+		if path, fromFile := lookupEnv(fileKey); fromFile {
+			if ok {
+				return fmt.Errorf("environment variables %s and %s are both set", key, fileKey)
+			}
+
+			contents, err := readSecretFile(path)
+			if err != nil {
+				return fmt.Errorf("environment variable %s: %w", fileKey, err)
+			}
+			raw, ok, key = contents, true, fileKey
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := setting.set(raw); err != nil {
+			return fmt.Errorf("environment variable %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// applyFlags overrides settings from -set name=value flags, in the order given.
+func applyFlags(config *Config, overrides []string) error {
+	byName := make(map[string]setting)
+	for _, setting := range settings(config) {
+		byName[strings.ToLower(setting.Name())] = setting
+	}
+
+	for _, override := range overrides {
+		name, raw, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("flag -set %q must look like name=value", override)
+		}
+
+		if base, fromFile := strings.CutSuffix(name, fileSuffix); fromFile {
+			contents, err := readSecretFile(raw)
+			if err != nil {
+				return fmt.Errorf("flag -set %s: %w", name, err)
+			}
+			name, raw = base, contents
+		}
+
+		setting, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf("flag -set: unknown setting %q", name)
+		}
+
+		if err := setting.set(raw); err != nil {
+			return fmt.Errorf("flag -set: %w", err)
+		}
+	}
+
+	return nil
+}
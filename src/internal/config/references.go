@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileSuffix marks a setting whose value is read from the named file, such as password_file in yaml,
+// PROFILE_DATABASE_PASSWORD_FILE in the environment or -set database.password_file=... on the command line.
+const fileSuffix = "_file"
+
+// reference matches ${file:/run/secrets/x} and ${env:X} inside a yaml value.
+var reference = regexp.MustCompile(`\$\{([a-z]+):([^}]*)\}`)
+
+// resolveReferences replaces *_file keys and ${...} references in the parsed yaml with what they point at.
+// Values bound for string settings are kept as strings so a secret such as "yes" or "0123" isn't reinterpreted.
+func resolveReferences(root *yaml.Node, lookupEnv func(string) (string, bool)) error {
+	stringSettings := make(map[string]bool)
+	for _, setting := range settings(&Config{}) {
+		stringSettings[setting.Name()] = setting.value.Kind() == reflect.String
+	}
+
+	return resolveNode(root, nil, stringSettings, lookupEnv)
+}
+
+func resolveNode(node *yaml.Node, path []string, stringSettings map[string]bool, lookupEnv func(string) (string, bool)) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := resolveNode(child, path, stringSettings, lookupEnv); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		if err := resolveFileKeys(node, path, stringSettings); err != nil {
+			return err
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			childPath := append(append([]string{}, path...), node.Content[i].Value)
+			if err := resolveNode(node.Content[i+1], childPath, stringSettings, lookupEnv); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !reference.MatchString(node.Value) {
+			return nil
+		}
+
+		resolved, err := interpolate(node.Value, lookupEnv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+		}
+
+		node.Value = resolved
+		if stringSettings[strings.Join(path, ".")] {
+			node.Tag = "!!str"
+		} else {
+			node.Tag = ""
+		}
+	}
+
+	return nil
+}
+
+// resolveFileKeys turns every key: value pair named like password_file into password: <file contents>, typed
+// like the setting it names so port_file still decodes as an int.
+func resolveFileKeys(node *yaml.Node, path []string, stringSettings map[string]bool) error {
+	keys := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys[node.Content[i].Value] = true
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		name, ok := strings.CutSuffix(key.Value, fileSuffix)
+		if !ok || value.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		settingPath := strings.Join(append(append([]string{}, path...), name), ".")
+		if keys[name] {
+			return fmt.Errorf("%s is set both directly and through %s", settingPath, key.Value)
+		}
+
+		contents, err := readSecretFile(value.Value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", settingPath, err)
+		}
+
+		key.Value = name
+		value.Value = contents
+		if stringSettings[settingPath] {
+			value.Tag = "!!str"
+			value.Style = yaml.DoubleQuotedStyle
+		} else {
+			value.Tag = ""
+			value.Style = 0
+		}
+	}
+
+	return nil
+}
+
+// interpolate expands every ${file:...} and ${env:...} reference in value.
+func interpolate(value string, lookupEnv func(string) (string, bool)) (string, error) {
+	var failed error
+	resolved := reference.ReplaceAllStringFunc(value, func(match string) string {
+		parts := reference.FindStringSubmatch(match)
+		scheme, target := parts[1], parts[2]
+
+		switch scheme {
+		case "file":
+			contents, err := readSecretFile(target)
+			if err != nil && failed == nil {
+				failed = err
+			}
+			return contents
+		case "env":
+			contents, ok := lookupEnv(target)
+			if !ok && failed == nil {
+				failed = fmt.Errorf("environment variable %s referenced by %s is not set", target, match)
+			}
+			return contents
+		default:
+			if failed == nil {
+				failed = fmt.Errorf("unknown reference %s, expected ${file:path} or ${env:NAME}", match)
+			}
+			return match
+		}
+	})
+
+	return resolved, failed
+}
+
+// readSecretFile reads a mounted secret, dropping the trailing newline most tools write.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading secret file: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decode resolves the references in contents against env and decodes the result over the defaults.
+func decode(t *testing.T, contents string, env map[string]string) (Config, error) {
+	t.Helper()
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(contents), &root); err != nil {
+		t.Fatalf("parsing yaml: %v", err)
+	}
+
+	config := Default()
+	if err := resolveReferences(&root, envFrom(env)); err != nil {
+		return Config{}, err
+	}
+
+	if err := root.Decode(&config); err != nil {
+		t.Fatalf("decoding yaml: %v", err)
+	}
+	return config, nil
+}
+
+func TestFileKeysAreReadAndTypedLikeTheirSetting(t *testing.T) {
+	contents := "database:\n" +
+		"  password_file: " + writeFile(t, "password", "0123\n") + "\n" +
+		"  username_file: " + writeFile(t, "username", "yes") + "\n" +
+		"  port_file: " + writeFile(t, "port", "3307\n") + "\n"
+
+	config, err := decode(t, contents, nil)
+	if err != nil {
+		t.Fatalf("resolving references: %v", err)
+	}
+
+	if config.Database.Password.Value() != "0123" {
+		t.Errorf("database.password = %q, want 0123 kept as a string", config.Database.Password.Value())
+	}
+	if config.Database.Username != "yes" {
+		t.Errorf("database.username = %q, want yes kept as a string", config.Database.Username)
+	}
+	if config.Database.Port != 3307 {
+		t.Errorf("database.port = %d, want 3307", config.Database.Port)
+	}
+}
+
+func TestFileKeyAndDirectKeyBothSet(t *testing.T) {
+	contents := "database:\n" +
+		"  password: direct\n" +
+		"  password_file: " + writeFile(t, "password", "hunter2") + "\n"
+
+	_, err := decode(t, contents, nil)
+	if err == nil || !strings.Contains(err.Error(), "database.password is set both directly and through password_file") {
+		t.Errorf("err = %v, want the duplicate setting reported", err)
+	}
+}
+
+func TestReferencesAreInterpolated(t *testing.T) {
+	contents := "database:\n" +
+		"  host: ${env:DB_HOST}.internal\n" +
+		"  port: ${env:DB_PORT}\n" +
+		"  username: ${env:DB_USER}\n" +
+		"  password: ${file:" + writeFile(t, "password", "0123\n") + "}\n" +
+		"  allowZeroDateTime: ${env:ZERO_DATES}\n"
+
+	config, err := decode(t, contents, map[string]string{
+		"DB_HOST":    "mysql",
+		"DB_PORT":    "3307",
+		"DB_USER":    "no",
+		"ZERO_DATES": "true",
+	})
+	if err != nil {
+		t.Fatalf("resolving references: %v", err)
+	}
+
+	database := config.Database
+	if database.Host != "mysql.internal" || database.Port != 3307 || database.Username != "no" ||
+		database.Password.Value() != "0123" || !database.AllowZeroDateTime {
+		t.Errorf("database = %#v, want every reference expanded and typed like its setting", database)
+	}
+}
+
+func TestReferenceErrors(t *testing.T) {
+	tests := map[string]string{
+		"unset variable":   "database:\n  host: ${env:MISSING}\n",
+		"unknown scheme":   "database:\n  host: ${vault:db}\n",
+		"missing file":     "database:\n  host: ${file:" + filepath.Join(t.TempDir(), "missing") + "}\n",
+		"missing _file":    "database:\n  password_file: " + filepath.Join(t.TempDir(), "missing") + "\n",
+		"nested reference": "cache:\n  redis:\n    addr: ${env:MISSING}\n",
+	}
+
+	for name, contents := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decode(t, contents, nil); err == nil {
+				t.Error("reference was resolved")
+			}
+		})
+	}
+}
+
+func TestSecretsAreRedacted(t *testing.T) {
+	config := Default()
+	config.Database.Password = "db-password"
+	config.JWTSettings.Key = "jwt-signing-key"
+	config.Cache.Redis.Password = "redis-password"
+
+	printed := config.String()
+	for _, secret := range []string{"db-password", "jwt-signing-key", "redis-password"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("String() leaks %s", secret)
+		}
+	}
+	if !strings.Contains(printed, "password: '[REDACTED]'") {
+		t.Errorf("String() = %s, want redacted passwords", printed)
+	}
+
+	changed := config
+	changed.Database.Password = "new-db-password"
+	changes := diff(&config, &changed)
+	if len(changes) != 1 {
+		t.Fatalf("diff() = %v, want one change", changes)
+	}
+	if strings.Contains(changes[0], "db-password") || !strings.Contains(changes[0], redacted) {
+		t.Errorf("diff() = %q, want the password change redacted", changes[0])
+	}
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// reloadDebounce lets an editor or a kubernetes volume update finish writing before the file is read.
+const reloadDebounce = 250 * time.Millisecond
+
+// Reloader rebuilds the config from the same args it was loaded with and swaps it into the store. Only live
+// settings are taken from the new config, any other change is logged as needing a restart.
+type Reloader struct {
+	store  *Store
+	args   []string
+	logger *zap.Logger
+	hooks  []func(*Config)
+	mu     sync.Mutex
+}
+
+func NewReloader(store *Store, args []string, logger *zap.Logger) *Reloader {
+	return &Reloader{
+		store:  store,
+		args:   args,
+		logger: logger,
+	}
+}
+
+// OnReload registers fn to be called with each new snapshot, for settings that have to be pushed rather than read.
+func (r *Reloader) OnReload(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hooks = append(r.hooks, fn)
+}
+
+// Reload rebuilds and validates the config. An invalid config is rejected and logged with the changes it
+// would have made, the current snapshot is kept.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	candidate, err := build(r.args)
+	if err != nil {
+		r.logger.Error("config reload failed, keeping the current config", zap.Error(err))
+		return err
+	}
+
+	current := r.store.Get()
+	changes := diff(current, candidate)
+
+	if err := validateConfig(candidate); err != nil {
+		r.logger.Error("config reload rejected, keeping the current config",
+			zap.Strings("changes", changes), zap.Error(err))
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	if len(changes) == 0 {
+		r.logger.Info("config reloaded, nothing changed")
+		return nil
+	}
+
+	next := *current
+	applyLive(&next, candidate)
+	r.store.current.Store(&next)
+	r.logger.Info("config reloaded", zap.Strings("changes", changes))
+
+	for _, hook := range r.hooks {
+		hook(&next)
+	}
+
+	return nil
+}
+
+// Watch reloads whenever the config file changes or the process receives SIGHUP, until ctx is done.
+func (r *Reloader) Watch(ctx context.Context) error {
+	commandLine, err := parseArgs(r.args)
+	if err != nil {
+		return err
+	}
+
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error watching config file: %w", err)
+	}
+	defer watcher.Close()
+
+	// The directory is watched rather than the file, editors and kubernetes replace the file instead of
+	// writing to it, which would drop a watch on the file itself.
+	path, _ := resolvePath(commandLine.path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		r.logger.Sugar().Warnf("not watching config file %s, reload with SIGHUP instead: %v", path, err)
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hangups:
+			r.Reload()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if changesFile(event, path) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			r.logger.Sugar().Warnf("error watching config file %s, %v", path, err)
+		case <-debounce:
+			debounce = nil
+			r.Reload()
+		}
+	}
+}
+
+// changesFile reports whether event touches the config file, including the ..data symlink swap kubernetes
+// uses to update a mounted config map.
+func changesFile(event fsnotify.Event, path string) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+
+	name := filepath.Clean(event.Name)
+	return name == filepath.Clean(path) || filepath.Base(name) == "..data"
+}
+
+// diff lists each setting that differs between from and to, noting those that only apply after a restart.
+func diff(from, to *Config) []string {
+	before, after := settings(from), settings(to)
+
+	var changes []string
+	for i := range before {
+		if reflect.DeepEqual(before[i].value.Interface(), after[i].value.Interface()) {
+			continue
+		}
+
+		change := fmt.Sprintf("%s: %v -> %v", before[i].Name(), before[i].value.Interface(), after[i].value.Interface())
+		if !before[i].live {
+			change += " (restart required)"
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// applyLive copies every live setting from source into config.
+func applyLive(config *Config, source *Config) {
+	targets, values := settings(config), settings(source)
+	for i := range targets {
+		if targets[i].live {
+			targets[i].value.Set(values[i].value)
+		}
+	}
+}
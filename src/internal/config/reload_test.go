@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// validFile is a config file that passes validation, extra is appended to its timeouts section.
+func validFile(host string, extra string) string {
+	return "database:\n" +
+		"  host: " + host + "\n" +
+		"  name: profiles\n" +
+		"  username: profile-service\n" +
+		"userclientoptions:\n" +
+		"  baseUrl: http://users.internal\n" +
+		"jwtsettings:\n" +
+		"  key: " + strings.Repeat("j", minKeyLength) + "\n" +
+		"pagination:\n" +
+		"  cursorKey: " + strings.Repeat("c", minKeyLength) + "\n" +
+		"timeouts:\n" +
+		"  list: 1m\n" +
+		extra
+}
+
+// newReloader loads contents from a temporary file and returns a reloader over it, with the path to rewrite.
+func newReloader(t *testing.T, contents string) (*Reloader, *Store, string, *observer.ObservedLogs) {
+	t.Helper()
+
+	path := writeFile(t, "config.yaml", contents)
+	args := []string{"-config", path}
+
+	config, err := Load(args)
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+
+	core, logs := observer.New(zap.InfoLevel)
+	store := NewStore(config)
+	return NewReloader(store, args, zap.New(core)), store, path, logs
+}
+
+func rewrite(t *testing.T, path string, contents string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("rewriting config: %v", err)
+	}
+}
+
+func TestReloadAppliesOnlyLiveSettings(t *testing.T) {
+	reloader, store, path, logs := newReloader(t, validFile("mysql", "  request: 3s\n"))
+
+	var hooked *Config
+	reloader.OnReload(func(config *Config) { hooked = config })
+
+	rewrite(t, path, validFile("mysql-replica", "  request: 5s\n"))
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("reloading: %v", err)
+	}
+
+	current := store.Get()
+	if current.Timeouts.Request != 5*time.Second {
+		t.Errorf("timeouts.request = %v, want the reloaded 5s", current.Timeouts.Request)
+	}
+	if current.Database.Host != "mysql" {
+		t.Errorf("database.host = %q, want mysql kept until a restart", current.Database.Host)
+	}
+	if hooked != current {
+		t.Error("OnReload hook wasn't called with the new snapshot")
+	}
+
+	reloaded := logs.FilterMessage("config reloaded").All()
+	if len(reloaded) != 1 {
+		t.Fatalf("logged %d reloads, want 1", len(reloaded))
+	}
+
+	changes := toStrings(reloaded[0].ContextMap()["changes"])
+	if !slices.Contains(changes, "database.host: mysql -> mysql-replica (restart required)") {
+		t.Errorf("changes = %q, want database.host reported as needing a restart", changes)
+	}
+	if !slices.Contains(changes, "timeouts.request: 3s -> 5s") {
+		t.Errorf("changes = %q, want timeouts.request applied live", changes)
+	}
+}
+
+func TestInvalidReloadKeepsTheCurrentSnapshot(t *testing.T) {
+	reloader, store, path, logs := newReloader(t, validFile("mysql", "  request: 3s\n"))
+	before := store.Get()
+
+	rewrite(t, path, validFile("mysql", "  request: 0s\n"))
+	if err := reloader.Reload(); err == nil {
+		t.Fatal("invalid config was reloaded")
+	}
+
+	if store.Get() != before {
+		t.Error("the snapshot was replaced by an invalid config")
+	}
+	if logs.FilterMessage("config reload rejected, keeping the current config").Len() != 1 {
+		t.Error("the rejected reload wasn't logged")
+	}
+}
+
+func TestReloadWithoutChangesKeepsTheSnapshot(t *testing.T) {
+	reloader, store, _, _ := newReloader(t, validFile("mysql", ""))
+	before := store.Get()
+
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("reloading: %v", err)
+	}
+
+	if store.Get() != before {
+		t.Error("an unchanged config replaced the snapshot")
+	}
+}
+
+func TestDiffNotesRestartRequired(t *testing.T) {
+	from := Default()
+	to := Default()
+	to.Log.Level = "debug"
+	to.Pool.MaxOpenConns = 50
+
+	changes := diff(&from, &to)
+	want := []string{
+		"pool.maxOpenConns: 25 -> 50 (restart required)",
+		"log.level: info -> debug",
+	}
+	if !slices.Equal(changes, want) {
+		t.Errorf("diff() = %q, want %q", changes, want)
+	}
+}
+
+// toStrings reads a zap.Strings field back out of an observed log entry.
+func toStrings(field interface{}) []string {
+	values, _ := field.([]interface{})
+	found := make([]string, 0, len(values))
+	for _, value := range values {
+		found = append(found, value.(string))
+	}
+	return found
+}
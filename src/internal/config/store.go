@@ -0,0 +1,20 @@
+package config
+
+import "sync/atomic"
+
+// Store holds the current config snapshot. Components holding a Store read live settings through Get on each
+// use, so a reload takes effect without a restart.
+type Store struct {
+	current atomic.Pointer[Config]
+}
+
+func NewStore(config *Config) *Store {
+	store := &Store{}
+	store.current.Store(config)
+	return store
+}
+
+// Get returns the current snapshot, it must not be modified.
+func (s *Store) Get() *Config {
+	return s.current.Load()
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// minKeyLength is the shortest signing key accepted, HMAC-SHA256 keys should be at least as long as the hash.
+const minKeyLength = 32
+
+var (
+	knownDrivers       = []string{"mysql"}
+	knownSSLModes      = []string{"", "false", "true", "skip-verify", "preferred"}
+	knownCacheBackends = []string{"memory", "redis", "near"}
+)
+
+// validateConfig checks every section and reports all of the problems found at once.
+func validateConfig(config *Config) error {
+	v := &validation{}
+
+	validateServer(v, config.Server)
+	validateDatabase(v, config.Database)
+	validatePool(v, config.Pool)
+	validateTimeouts(v, config.Timeouts)
+	validateUserClient(v, config.UserClientOptions)
+	validateJWT(v, config.JWTSettings)
+	validatePagination(v, config.Pagination, config.JWTSettings)
+	validateCache(v, config.Cache)
+	validateLog(v, config.Log)
+
+	return errors.Join(v.problems...)
+}
+
+type validation struct {
+	problems []error
+}
+
+func (v *validation) check(ok bool, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Errorf(format, args...))
+	}
+}
+
+func validateServer(v *validation, server ServerSettings) {
+	_, _, err := net.SplitHostPort(server.Addr)
+	v.check(err == nil, "server.addr %q must be a host:port or :port address", server.Addr)
+	_, _, err = net.SplitHostPort(server.MetricsAddr)
+	v.check(err == nil, "server.metricsAddr %q must be a host:port or :port address", server.MetricsAddr)
+	v.check(server.MetricsAddr != server.Addr, "server.metricsAddr must differ from server.addr")
+	v.check(server.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+}
+
+func validateDatabase(v *validation, database DBConfig) {
+	v.check(slices.Contains(knownDrivers, database.Driver), "database.driver %q is not one of %q", database.Driver, knownDrivers)
+	v.check(database.Host != "", "database.host is required")
+	v.check(database.Port > 0 && database.Port <= 65535, "database.port %d must be between 1 and 65535", database.Port)
+	v.check(database.Name != "", "database.name is required")
+	v.check(database.Username != "", "database.username is required")
+	v.check(slices.Contains(knownSSLModes, database.SSlMode), "database.sslMode %q is not one of %q", database.SSlMode, knownSSLModes)
+}
+
+func validatePool(v *validation, pool PoolSettings) {
+	v.check(pool.MaxOpenConns >= 0, "pool.maxOpenConns can't be negative")
+	v.check(pool.MaxIdleConns >= 0, "pool.maxIdleConns can't be negative")
+	v.check(pool.MaxOpenConns == 0 || pool.MaxIdleConns <= pool.MaxOpenConns,
+		"pool.maxIdleConns %d can't be more than pool.maxOpenConns %d", pool.MaxIdleConns, pool.MaxOpenConns)
+	v.check(pool.ConnMaxLifetime >= 0, "pool.connMaxLifetime can't be negative")
+}
+
+func validateTimeouts(v *validation, timeouts TimeoutSettings) {
+	v.check(timeouts.List > 0, "timeouts.list must be positive")
+	v.check(timeouts.Request > 0, "timeouts.request must be positive")
+	v.check(timeouts.UserClient > 0, "timeouts.userClient must be positive")
+	v.check(timeouts.UserClientIdle >= 0, "timeouts.userClientIdle can't be negative")
+}
+
+func validateUserClient(v *validation, userClient UserClient) {
+	validateBaseUrl(v, userClient.URL)
+	v.check(userClient.CircuitBreaker.FailureThreshold > 0, "userclientoptions.circuitBreaker.failureThreshold must be positive")
+	v.check(userClient.CircuitBreaker.OpenTimeout > 0, "userclientoptions.circuitBreaker.openTimeout must be positive")
+}
+
+func validateBaseUrl(v *validation, baseUrl string) {
+	if baseUrl == "" {
+		v.check(false, "userclientoptions.baseUrl is required")
+		return
+	}
+
+	parsed, err := url.Parse(baseUrl)
+	if err != nil {
+		v.check(false, "userclientoptions.baseUrl is not a valid url: %v", err)
+		return
+	}
+
+	v.check(parsed.Scheme == "http" || parsed.Scheme == "https", "userclientoptions.baseUrl must be an http or https url")
+	v.check(parsed.Host != "", "userclientoptions.baseUrl must include a host")
+}
+
+func validateJWT(v *validation, jwt JWTSettings) {
+	v.check(len(jwt.Key.Value()) >= minKeyLength, "jwtsettings.key must be at least %d bytes", minKeyLength)
+	v.check(jwt.ExpiresInMinutes >= 0, "jwtsettings.expiresInMinutes can't be negative")
+	v.check(jwt.RefreshTokenExpiresIn >= 0, "jwtsettings.refreshTokenExpiresIn can't be negative")
+}
+
+func validatePagination(v *validation, pagination PaginationSettings, jwt JWTSettings) {
+	v.check(len(pagination.CursorKey.Value()) >= minKeyLength, "pagination.cursorKey must be at least %d bytes", minKeyLength)
+	v.check(pagination.CursorKey == "" || pagination.CursorKey != jwt.Key, "pagination.cursorKey must not be the jwt key")
+	v.check(pagination.MaxSize > 0, "pagination.maxSize must be positive")
+	v.check(pagination.DefaultSize > 0 && pagination.DefaultSize <= pagination.MaxSize,
+		"pagination.defaultSize %d must be between 1 and pagination.maxSize", pagination.DefaultSize)
+}
+
+func validateCache(v *validation, cache CacheSettings) {
+	v.check(cache.MaxEntries >= 0, "cache.maxEntries can't be negative")
+	v.check(cache.MaxBytes >= 0, "cache.maxBytes can't be negative")
+	v.check(cache.CleanupInterval > 0, "cache.cleanupInterval must be positive")
+	v.check(cache.NegativeTTL >= 0, "cache.negativeTtl can't be negative")
+	v.check(cache.StaleTTL >= 0, "cache.staleTtl can't be negative")
+	v.check(cache.Jitter >= 0 && cache.Jitter < 1, "cache.jitter %v must be at least 0 and below 1", cache.Jitter)
+	v.check(slices.Contains(knownCacheBackends, cache.Backend), "cache.backend %q is not one of %q", cache.Backend, knownCacheBackends)
+
+	if cache.Backend == "redis" || cache.Backend == "near" {
+		v.check(cache.Redis.Addr != "", "cache.redis.addr is required for the %s backend", cache.Backend)
+		v.check(cache.Redis.DB >= 0, "cache.redis.db can't be negative")
+	}
+	if cache.Backend == "near" {
+		v.check(cache.Redis.Channel != "", "cache.redis.channel is required for the near backend")
+	}
+
+	v.check(cache.TTL.Profile > 0, "cache.ttl.profile must be positive")
+	v.check(cache.TTL.Exists > 0, "cache.ttl.exists must be positive")
+	v.check(cache.TTL.Relationship > 0, "cache.ttl.relationship must be positive")
+	v.check(cache.TTL.User > 0, "cache.ttl.user must be positive")
+
+	if cache.Warmup.Enabled {
+		v.check(cache.Warmup.Count > 0, "cache.warmup.count must be positive when warmup is enabled")
+		v.check(cache.Warmup.Budget > 0, "cache.warmup.budget must be positive when warmup is enabled")
+	}
+}
+
+func validateLog(v *validation, log LogSettings) {
+	_, err := zapcore.ParseLevel(log.Level)
+	v.check(err == nil, "log.level %q is not a known level", log.Level)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const testCursorKey = "0123456789abcdef0123456789abcdef"
+
+func TestCursorSignerRoundTrip(t *testing.T) {
+	signer := NewCursorSigner(testCursorKey)
+	scope := CursorScope("followers", uuid.New())
+
+	want := Cursor{CreatedAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), Id: 42, Backward: true}
+	token, err := signer.Encode(want, scope)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := signer.Decode(token, scope)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.Id != want.Id || got.Backward != want.Backward {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+}
+
+func TestCursorSignerRejectsTamperedTokens(t *testing.T) {
+	signer := NewCursorSigner(testCursorKey)
+	scope := CursorScope("followers", uuid.New())
+
+	token, err := signer.Encode(Cursor{CreatedAt: time.Now().UTC(), Id: 42}, scope)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	encoded, signature, _ := strings.Cut(token, ".")
+
+	forged, err := signer.Encode(Cursor{CreatedAt: time.Now().UTC(), Id: 1}, scope)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	otherKey, err := NewCursorSigner(strings.Repeat("k", 32)).Encode(Cursor{Id: 42}, scope)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	tests := map[string]string{
+		"no signature":            encoded,
+		"empty":                   "",
+		"payload swapped":         forgedPayload + "." + signature,
+		"signature not base64":    encoded + ".!!!",
+		"signature truncated":     encoded + "." + signature[:len(signature)-2],
+		"payload not json":        base64.RawURLEncoding.EncodeToString([]byte("not json")) + "." + signature,
+		"signed with another key": otherKey,
+	}
+	for name, tampered := range tests {
+		if _, err := signer.Decode(tampered, scope); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: Decode returned %v, want ErrInvalidCursor", name, err)
+		}
+	}
+}
+
+func TestCursorSignerRejectsOtherScopes(t *testing.T) {
+	signer := NewCursorSigner(testCursorKey)
+	id := uuid.New()
+
+	token, err := signer.Encode(Cursor{CreatedAt: time.Now().UTC(), Id: 42}, CursorScope("followers", id))
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	tests := map[string]string{
+		"other profile": CursorScope("followers", uuid.New()),
+		"other list":    CursorScope("following", id),
+	}
+	for name, scope := range tests {
+		if _, err := signer.Decode(token, scope); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: Decode returned %v, want ErrInvalidCursor", name, err)
+		}
+	}
+}
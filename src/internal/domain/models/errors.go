@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+var (
+	ErrProfileNotFound  = errors.New("profile not found")
+	ErrAlreadyFollowing = errors.New("already following profile")
+	ErrNotFollowing     = errors.New("not following profile")
+	ErrCannotFollowSelf = errors.New("a profile can't follow itself")
+
+	ErrFollowRequestExists   = errors.New("follow request already exists")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+
+	ErrForbidden      = errors.New("caller is not allowed to view this resource")
+	ErrInvalidCursor  = errors.New("invalid cursor")
+	ErrInvalidProfile = errors.New("invalid profile")
+
+	ErrAlreadyBlocked  = errors.New("profile already blocked")
+	ErrNotBlocked      = errors.New("profile is not blocked")
+	ErrCannotBlockSelf = errors.New("a profile can't block itself")
+	ErrBlocked         = errors.New("profiles have blocked each other")
+
+	ErrAlreadyMuted   = errors.New("profile already muted")
+	ErrNotMuted       = errors.New("profile is not muted")
+	ErrCannotMuteSelf = errors.New("a profile can't mute itself")
+)
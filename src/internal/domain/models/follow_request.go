@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FollowRequest struct {
+	UserId            uuid.UUID `json:"user_id"`
+	Username          string    `json:"user_name"`
+	RequesterId       uuid.UUID `json:"requester_id"`
+	RequesterUsername string    `json:"requester_user_name"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Mute struct {
+	UserId    uuid.UUID `json:"user_id"`
+	MutedId   uuid.UUID `json:"muted_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MuteCheckRequest asks which of ids the caller has muted.
+type MuteCheckRequest struct {
+	Ids []uuid.UUID `json:"ids" validate:"required,min=1,max=500"`
+}
+
+// ServiceMuteCheckRequest asks which of ids UserId has muted, only other services may name the user.
+type ServiceMuteCheckRequest struct {
+	UserId uuid.UUID   `json:"user_id" validate:"required"`
+	Ids    []uuid.UUID `json:"ids" validate:"required,min=1,max=500"`
+}
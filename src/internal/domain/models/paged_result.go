@@ -6,3 +6,10 @@ type PagedResult[T any] struct {
 	Size  int `json:"size"`
 	Total int `json:"total"`
 }
+
+type CursorPagedResult[T any] struct {
+	Items      T      `json:"items"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
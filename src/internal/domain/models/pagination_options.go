@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -12,28 +13,70 @@ type PageinationOptions struct {
 	Size int
 }
 
-func GetOptions(c *gin.Context) PageinationOptions {
-	pageString := c.DefaultQuery("page", "1")
-	sizeString := c.DefaultQuery("size", "100")
+// PageLimits are the page size used when none is asked for and the largest allowed, they apply to cursor
+// limits too.
+type PageLimits struct {
+	DefaultSize int
+	MaxSize     int
+}
 
-	var pageinationOptions PageinationOptions
+func GetOptions(c *gin.Context, limits PageLimits) PageinationOptions {
+	pageString := c.DefaultQuery("page", "1")
+	sizeString := c.DefaultQuery("size", strconv.Itoa(limits.DefaultSize))
 
 	page, err := strconv.Atoi(pageString)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, "page number has to be a whole number")
+		return PageinationOptions{}
+	}
+	if page < 1 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "page number has to be at least 1")
+		return PageinationOptions{}
 	}
 
 	size, err := strconv.Atoi(sizeString)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, "page size has to be a whole number")
+		return PageinationOptions{}
 	}
-	if size > 250 {
-		c.AbortWithStatusJSON(http.StatusBadRequest, "page size has to be between 1 and 250")
+	if size < 1 || size > limits.MaxSize {
+		c.AbortWithStatusJSON(http.StatusBadRequest, fmt.Sprintf("page size has to be between 1 and %d", limits.MaxSize))
+		return PageinationOptions{}
 	}
 
-	pageinationOptions.Page = page
-	pageinationOptions.Size = size
+	return PageinationOptions{
+		Page: page,
+		Size: size,
+	}
+}
 
-	return pageinationOptions
+type CursorOptions struct {
+	Cursor string
+	Limit  int
+}
 
+// UsesCursor reports whether the request asked for cursor pagination rather than page and size.
+func UsesCursor(c *gin.Context) bool {
+	_, hasCursor := c.GetQuery("cursor")
+	_, hasLimit := c.GetQuery("limit")
+	return hasCursor || hasLimit
+}
+
+func GetCursorOptions(c *gin.Context, limits PageLimits) CursorOptions {
+	limitString := c.DefaultQuery("limit", strconv.Itoa(limits.DefaultSize))
+
+	limit, err := strconv.Atoi(limitString)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "limit has to be a whole number")
+		return CursorOptions{}
+	}
+	if limit < 1 || limit > limits.MaxSize {
+		c.AbortWithStatusJSON(http.StatusBadRequest, fmt.Sprintf("limit has to be between 1 and %d", limits.MaxSize))
+		return CursorOptions{}
+	}
+
+	return CursorOptions{
+		Cursor: c.Query("cursor"),
+		Limit:  limit,
+	}
 }
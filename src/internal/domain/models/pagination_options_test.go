@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limits := PageLimits{DefaultSize: 10, MaxSize: 50}
+
+	tests := []struct {
+		query   string
+		want    PageinationOptions
+		aborted bool
+	}{
+		{"", PageinationOptions{Page: 1, Size: 10}, false},
+		{"?page=3&size=50", PageinationOptions{Page: 3, Size: 50}, false},
+		{"?page=0", PageinationOptions{}, true},
+		{"?page=-1", PageinationOptions{}, true},
+		{"?page=one", PageinationOptions{}, true},
+		{"?size=0", PageinationOptions{}, true},
+		{"?size=-5", PageinationOptions{}, true},
+		{"?size=51", PageinationOptions{}, true},
+		{"?size=ten", PageinationOptions{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest(http.MethodGet, "/followers"+test.query, nil)
+
+			options := GetOptions(c, limits)
+			if options != test.want {
+				t.Errorf("GetOptions() = %+v, want %+v", options, test.want)
+			}
+			if c.IsAborted() != test.aborted {
+				t.Errorf("aborted = %v, want %v", c.IsAborted(), test.aborted)
+			}
+			if test.aborted && recorder.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", recorder.Code)
+			}
+		})
+	}
+}
@@ -1,10 +1,72 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
 
 type Profile struct {
 	UserId         uuid.UUID `json:"user_id" validate:"required"`
 	FollowerCount  int32     `json:"follow_count"`
 	FollowingCount int32     `json:"following_count"`
-	Private        bool      `json:"private" validate:"required"`
+	Private        bool      `json:"private"`
+	DisplayName    string    `json:"display_name" validate:"omitempty,max=50"`
+	Bio            string    `json:"bio" validate:"omitempty,max=160"`
+	AvatarURL      string    `json:"avatar_url" validate:"omitempty,http_url,max=2048"`
+	Location       string    `json:"location" validate:"omitempty,max=100"`
+	Website        string    `json:"website" validate:"omitempty,http_url,max=2048"`
+}
+
+// mutableProfileFields are the json fields a profile owner is allowed to patch.
+var mutableProfileFields = map[string]bool{
+	"private":      true,
+	"display_name": true,
+	"bio":          true,
+	"avatar_url":   true,
+	"location":     true,
+	"website":      true,
+}
+
+// MergePatch applies a JSON Merge Patch (RFC 7396) to the profile, a null value resets the field to its default.
+func (p Profile) MergePatch(patch []byte) (Profile, error) {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return Profile{}, fmt.Errorf("patch must be a json object: %w", err)
+	}
+
+	current, err := json.Marshal(p)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(current, &fields); err != nil {
+		return Profile{}, err
+	}
+
+	for name, value := range changes {
+		if !mutableProfileFields[name] {
+			return Profile{}, fmt.Errorf("field %s can't be updated", name)
+		}
+
+		if string(value) == "null" {
+			delete(fields, name)
+			continue
+		}
+		fields[name] = value
+	}
+
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	var updated Profile
+	if err := json.Unmarshal(merged, &updated); err != nil {
+		return Profile{}, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	return updated, nil
 }
@@ -0,0 +1,16 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Relationship describes how a viewer and a target profile are connected, from the viewers point of view.
+type Relationship struct {
+	ViewerId        uuid.UUID `json:"viewer_id"`
+	TargetId        uuid.UUID `json:"target_id"`
+	Following       bool      `json:"following"`
+	FollowedBy      bool      `json:"followed_by"`
+	Mutual          bool      `json:"mutual"`
+	RequestPending  bool      `json:"request_pending"`
+	RequestReceived bool      `json:"request_received"`
+	Blocking        bool      `json:"blocking"`
+	BlockedBy       bool      `json:"blocked_by"`
+}
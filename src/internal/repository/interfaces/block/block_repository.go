@@ -0,0 +1,14 @@
+package blockInterface
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BlockRepository interface {
+	Block(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) error
+	Unblock(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) error
+	IsBlocked(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) (bool, error)
+	EitherBlocked(a uuid.UUID, b uuid.UUID, ctx context.Context) (bool, error)
+}
@@ -0,0 +1,16 @@
+package followInterface
+
+import (
+	"context"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type FollowRequestRepository interface {
+	Create(user domain.User, requester domain.User, ctx context.Context) error
+	GetIncoming(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.FollowRequest], error)
+	GetOutgoing(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.FollowRequest], error)
+	Approve(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error
+	Delete(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error
+}
@@ -8,5 +8,10 @@ import (
 )
 
 type FollowerRetrivalRepository interface {
-	GetPage(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error)
+	GetPage(id uuid.UUID, viewerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error)
+	GetFollowingPage(id uuid.UUID, viewerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error)
+	GetCursorPage(id uuid.UUID, viewerId uuid.UUID, cursor *domain.Cursor, limit int, ctx context.Context) (*domain.KeysetPage[[]domain.User], error)
+	GetFollowingCursorPage(id uuid.UUID, viewerId uuid.UUID, cursor *domain.Cursor, limit int, ctx context.Context) (*domain.KeysetPage[[]domain.User], error)
+	IsFollowing(id uuid.UUID, followerId uuid.UUID, ctx context.Context) (bool, error)
+	GetRelationship(viewerId uuid.UUID, targetId uuid.UUID, ctx context.Context) (*domain.Relationship, error)
 }
@@ -0,0 +1,13 @@
+package followInterface
+
+import (
+	"context"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type FollowerWriterRepository interface {
+	Follow(user domain.User, follower domain.User, ctx context.Context) error
+	Unfollow(id uuid.UUID, followerId uuid.UUID, ctx context.Context) error
+}
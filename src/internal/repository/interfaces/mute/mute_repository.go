@@ -0,0 +1,15 @@
+package muteInterface
+
+import (
+	"context"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+type MuteRepository interface {
+	Mute(muterId uuid.UUID, mutedId uuid.UUID, ctx context.Context) error
+	Unmute(muterId uuid.UUID, mutedId uuid.UUID, ctx context.Context) error
+	GetPage(muterId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.Mute], error)
+	AreMuted(muterId uuid.UUID, ids []uuid.UUID, ctx context.Context) (map[uuid.UUID]bool, error)
+}
@@ -10,4 +10,5 @@ import (
 type ProfileRetrievalRepository interface {
 	GetById(id uuid.UUID, ctx context.Context) (*domain.Profile, error)
 	ProfileExits(id uuid.UUID, ctx context.Context) (bool, error)
+	GetTopByFollowerCount(limit int, ctx context.Context) ([]domain.Profile, error)
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/google/uuid"
 )
 
 type ProfileWriterRepository interface {
 	Create(profile domain.Profile, ctx context.Context) error
+	Update(id uuid.UUID, apply func(domain.Profile) (domain.Profile, error), ctx context.Context) error
+	Delete(id uuid.UUID, ctx context.Context) ([]uuid.UUID, error)
 }
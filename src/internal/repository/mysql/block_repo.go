@@ -0,0 +1,109 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	blockInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/block"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BlockRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewBlockRepository(db *sql.DB, logger *zap.Logger) blockInterface.BlockRepository {
+	return &BlockRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Block stores the block and tears down any follows or follow requests between the two profiles.
+func (r *BlockRepository) Block(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) error {
+	err := withTransaction(r.db, ctx, func(tx *sql.Tx) error {
+		query := `INSERT IGNORE INTO block(blockerGuid, blockedGuid) VALUES(?, ?)`
+
+		result, err := tx.ExecContext(ctx, query, blockerId, blockedId)
+		if err != nil {
+			return fmt.Errorf("unable to insert block: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("unable to read affected rows: %w", err)
+		}
+		if affected == 0 {
+			return domain.ErrAlreadyBlocked
+		}
+
+		if _, err := deleteFollow(tx, blockerId, blockedId, ctx); err != nil {
+			return err
+		}
+		if _, err := deleteFollow(tx, blockedId, blockerId, ctx); err != nil {
+			return err
+		}
+
+		query = `DELETE FROM follow_request
+				 WHERE (userGuid = ? AND requesterGuid = ?) OR (userGuid = ? AND requesterGuid = ?)`
+		if _, err := tx.ExecContext(ctx, query, blockerId, blockedId, blockedId, blockerId); err != nil {
+			return fmt.Errorf("unable to delete follow requests: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infof("%s blocked %s", blockerId, blockedId)
+	return nil
+}
+
+func (r *BlockRepository) Unblock(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) error {
+	query := `DELETE FROM block WHERE blockerGuid = ? AND blockedGuid = ?`
+
+	result, err := r.db.ExecContext(ctx, query, blockerId, blockedId)
+	if err != nil {
+		return fmt.Errorf("unable to delete block: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotBlocked
+	}
+
+	r.logger.Sugar().Infof("%s unblocked %s", blockerId, blockedId)
+	return nil
+}
+
+func (r *BlockRepository) IsBlocked(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) (bool, error) {
+	var blocked bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM block WHERE blockerGuid = ? AND blockedGuid = ?)`,
+		blockerId, blockedId).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("checking block existence: %w", err)
+	}
+
+	return blocked, nil
+}
+
+// EitherBlocked reports whether a has blocked b or b has blocked a.
+func (r *BlockRepository) EitherBlocked(a uuid.UUID, b uuid.UUID, ctx context.Context) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM block
+			  WHERE (blockerGuid = ? AND blockedGuid = ?) OR (blockerGuid = ? AND blockedGuid = ?))`
+
+	var blocked bool
+	if err := r.db.QueryRowContext(ctx, query, a, b, b, a).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("checking block existence: %w", err)
+	}
+
+	return blocked, nil
+}
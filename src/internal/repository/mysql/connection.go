@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"sync"
-	"time"
 
 	configuration "github.com/RobsonDevCode/go-profile-service/src/internal/config"
 	_ "github.com/go-sql-driver/mysql" // MySQL driver
@@ -22,19 +21,22 @@ var (
 
 func NewUserDataBase(config configuration.Config) *sql.DB {
 	dbConfig := config.Database
-	fmt.Print("getting here")
+	pool := config.Pool
 	once.Do(func() {
 		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
-			dbConfig.Username, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Name)
+			dbConfig.Username, dbConfig.Password.Value(), dbConfig.Host, dbConfig.Port, dbConfig.Name)
+		if dbConfig.SSlMode != "" {
+			dsn += "&tls=" + dbConfig.SSlMode
+		}
 
 		db, err := sql.Open("mysql", dsn)
 		if err != nil {
 			log.Fatalf("Falied to open database connection: %v", err)
 		}
 
-		db.SetMaxOpenConns(25)
-		db.SetMaxIdleConns(5)
-		db.SetConnMaxLifetime(5 * time.Minute)
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
 		if err := db.Ping(); err != nil {
 			log.Fatalf("Failed to ping database after connection has been made: %v", err)
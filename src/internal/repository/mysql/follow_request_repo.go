@@ -0,0 +1,174 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	followInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/follow"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FollowRequestRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewFollowRequestRepository(db *sql.DB, logger *zap.Logger) followInterface.FollowRequestRepository {
+	return &FollowRequestRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *FollowRequestRepository) Create(user domain.User, requester domain.User, ctx context.Context) error {
+	err := withTransaction(r.db, ctx, func(tx *sql.Tx) error {
+		var following int
+		err := tx.QueryRowContext(ctx, `SELECT 1 FROM follower WHERE userGuid = ? AND followerGuid = ? LIMIT 1`,
+			user.Id, requester.Id).Scan(&following)
+		if err == nil {
+			return domain.ErrAlreadyFollowing
+		}
+		if err != sql.ErrNoRows {
+			return fmt.Errorf("unable to check existing follow: %w", err)
+		}
+
+		query := `INSERT IGNORE INTO follow_request(userGuid, username, requesterGuid, requesterUsername)
+				  VALUES(?, ?, ?, ?)`
+
+		result, err := tx.ExecContext(ctx, query, user.Id, user.Username, requester.Id, requester.Username)
+		if err != nil {
+			return fmt.Errorf("unable to insert follow request: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("unable to read affected rows: %w", err)
+		}
+		if affected == 0 {
+			return domain.ErrFollowRequestExists
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infof("%s requested to follow %s", requester.Id, user.Id)
+	return nil
+}
+
+func (r *FollowRequestRepository) GetIncoming(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.FollowRequest], error) {
+	return r.getPage("userGuid", id, pageinationOptions, ctx)
+}
+
+func (r *FollowRequestRepository) GetOutgoing(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.FollowRequest], error) {
+	return r.getPage("requesterGuid", id, pageinationOptions, ctx)
+}
+
+// getPage reads follow requests filtered on column, which must be either userGuid or requesterGuid.
+func (r *FollowRequestRepository) getPage(column string, id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.FollowRequest], error) {
+	offset := (pageinationOptions.Page - 1) * pageinationOptions.Size
+
+	query := fmt.Sprintf(`SELECT userGuid, username, requesterGuid, requesterUsername, createdAt
+			  FROM follow_request WHERE %s = ?
+			  ORDER BY createdAt, id
+			  LIMIT ? OFFSET ?`, column)
+
+	rows, err := r.db.QueryContext(ctx, query, id, pageinationOptions.Size, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []domain.FollowRequest
+
+	for rows.Next() {
+		var request domain.FollowRequest
+
+		if err := rows.Scan(&request.UserId, &request.Username,
+			&request.RequesterId, &request.RequesterUsername, &request.CreatedAt); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(id) FROM follow_request WHERE %s = ?`, column)
+	if err := r.db.QueryRowContext(ctx, countQuery, id).Scan(&total); err != nil {
+		return nil, fmt.Errorf("error getting count: %w", err)
+	}
+
+	return &domain.PagedResult[[]domain.FollowRequest]{
+		Items: requests,
+		Page:  pageinationOptions.Page,
+		Size:  pageinationOptions.Size,
+		Total: total,
+	}, nil
+}
+
+func (r *FollowRequestRepository) Approve(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error {
+	err := withTransaction(r.db, ctx, func(tx *sql.Tx) error {
+		query := `SELECT username, requesterUsername FROM follow_request
+				  WHERE userGuid = ? AND requesterGuid = ? FOR UPDATE`
+
+		user := domain.User{Id: id}
+		requester := domain.User{Id: requesterId}
+		if err := tx.QueryRowContext(ctx, query, id, requesterId).Scan(&user.Username, &requester.Username); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrFollowRequestNotFound
+			}
+			return fmt.Errorf("unable to read follow request: %w", err)
+		}
+
+		if err := deleteFollowRequest(tx, id, requesterId, ctx); err != nil {
+			return err
+		}
+
+		return insertFollow(tx, user, requester, ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infof("%s approved follow request from %s", id, requesterId)
+	return nil
+}
+
+func (r *FollowRequestRepository) Delete(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error {
+	err := withTransaction(r.db, ctx, func(tx *sql.Tx) error {
+		return deleteFollowRequest(tx, id, requesterId, ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infof("follow request from %s to %s removed", requesterId, id)
+	return nil
+}
+
+func deleteFollowRequest(tx *sql.Tx, id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error {
+	result, err := tx.ExecContext(ctx, `DELETE FROM follow_request WHERE userGuid = ? AND requesterGuid = ?`, id, requesterId)
+	if err != nil {
+		return fmt.Errorf("unable to delete follow request: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrFollowRequestNotFound
+	}
+
+	return nil
+}
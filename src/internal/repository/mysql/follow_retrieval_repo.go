@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
 	followInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/follow"
@@ -23,14 +24,37 @@ func NewFollowerRetrivalRepository(db *sql.DB, logger *zap.Logger) followInterfa
 	}
 }
 
-func (r *FollowerRetrivalRepository) GetPage(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error) {
+// GetPage returns the followers of id, leaving out any follower that has blocked viewerId.
+func (r *FollowerRetrivalRepository) GetPage(id uuid.UUID, viewerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error) {
 
-	offset := (pageinationOptions.Page - 1) * pageinationOptions.Size
+	query := `SELECT followerGuid, followerUsername FROM follower
+			  WHERE userGuid = ?
+			  AND NOT EXISTS (SELECT 1 FROM block WHERE blockerGuid = follower.followerGuid AND blockedGuid = ?)
+			  ORDER BY createdAt, id
+			  LIMIT ? OFFSET ?`
+
+	return r.getPage(query, r.GetCount, id, viewerId, pageinationOptions, ctx)
+}
+
+// GetFollowingPage returns the profiles id follows, leaving out any profile that has blocked viewerId.
+func (r *FollowerRetrivalRepository) GetFollowingPage(id uuid.UUID, viewerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error) {
 
-	query := `SELECT * FROM follower WHERE userGuid = ? 
+	query := `SELECT userGuid, username FROM follower
+			  WHERE followerGuid = ?
+			  AND NOT EXISTS (SELECT 1 FROM block WHERE blockerGuid = follower.userGuid AND blockedGuid = ?)
+			  ORDER BY createdAt, id
 			  LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, id, pageinationOptions.Size, offset)
+	return r.getPage(query, r.GetFollowingCount, id, viewerId, pageinationOptions, ctx)
+}
+
+func (r *FollowerRetrivalRepository) getPage(query string,
+	count func(id uuid.UUID, viewerId uuid.UUID, ctx context.Context) (int, error),
+	id uuid.UUID, viewerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error) {
+
+	offset := (pageinationOptions.Page - 1) * pageinationOptions.Size
+
+	rows, err := r.db.QueryContext(ctx, query, id, viewerId, pageinationOptions.Size, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +76,7 @@ func (r *FollowerRetrivalRepository) GetPage(id uuid.UUID, pageinationOptions do
 		return nil, fmt.Errorf("row error: %w", err)
 	}
 
-	total, err := r.GetCount(id, ctx)
+	total, err := count(id, viewerId, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error getting count: %w", err)
 	}
@@ -67,14 +91,164 @@ func (r *FollowerRetrivalRepository) GetPage(id uuid.UUID, pageinationOptions do
 	}, nil
 }
 
-func (r *FollowerRetrivalRepository) GetCount(id uuid.UUID, ctx context.Context) (int, error) {
+// GetCursorPage reads followers of id from cursor by follow time, a nil cursor starts from the oldest follow.
+func (r *FollowerRetrivalRepository) GetCursorPage(id uuid.UUID, viewerId uuid.UUID, cursor *domain.Cursor, limit int, ctx context.Context) (*domain.KeysetPage[[]domain.User], error) {
+	query := `SELECT id, createdAt, followerGuid, followerUsername FROM follower
+			  WHERE userGuid = ?
+			  AND NOT EXISTS (SELECT 1 FROM block WHERE blockerGuid = follower.followerGuid AND blockedGuid = ?)`
+
+	return r.getKeysetPage(query, id, viewerId, cursor, limit, ctx)
+}
+
+// GetFollowingCursorPage reads the profiles id follows from cursor by follow time.
+func (r *FollowerRetrivalRepository) GetFollowingCursorPage(id uuid.UUID, viewerId uuid.UUID, cursor *domain.Cursor, limit int, ctx context.Context) (*domain.KeysetPage[[]domain.User], error) {
+	query := `SELECT id, createdAt, userGuid, username FROM follower
+			  WHERE followerGuid = ?
+			  AND NOT EXISTS (SELECT 1 FROM block WHERE blockerGuid = follower.userGuid AND blockedGuid = ?)`
+
+	return r.getKeysetPage(query, id, viewerId, cursor, limit, ctx)
+}
+
+// getKeysetPage finishes query with the keyset predicate and ordering, reading one extra row to detect more results.
+func (r *FollowerRetrivalRepository) getKeysetPage(query string, id uuid.UUID, viewerId uuid.UUID,
+	cursor *domain.Cursor, limit int, ctx context.Context) (*domain.KeysetPage[[]domain.User], error) {
+
+	args := []any{id, viewerId}
+	backward := cursor != nil && cursor.Backward
+
+	if cursor != nil {
+		if backward {
+			query += ` AND (createdAt < ? OR (createdAt = ? AND id < ?))`
+		} else {
+			query += ` AND (createdAt > ? OR (createdAt = ? AND id > ?))`
+		}
+		args = append(args, cursor.CreatedAt, cursor.CreatedAt, cursor.Id)
+	}
+
+	if backward {
+		query += ` ORDER BY createdAt DESC, id DESC LIMIT ?`
+	} else {
+		query += ` ORDER BY createdAt, id LIMIT ?`
+	}
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	var positions []domain.Cursor
+
+	for rows.Next() {
+		var user domain.User
+		var position domain.Cursor
+
+		if err := rows.Scan(&position.Id, &position.CreatedAt, &user.Id, &user.Username); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		users = append(users, user)
+		positions = append(positions, position)
+	}
 
-	query := `SELECT COUNT(userGuid) WHERE userGuid = ?`
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	page := &domain.KeysetPage[[]domain.User]{
+		HasMore: len(users) > limit,
+	}
+	if page.HasMore {
+		users = users[:limit]
+		positions = positions[:limit]
+	}
+
+	if backward {
+		slices.Reverse(users)
+		slices.Reverse(positions)
+	}
+
+	page.Items = users
+	if len(positions) > 0 {
+		page.First = positions[0]
+		page.Last = positions[len(positions)-1]
+	}
+
+	return page, nil
+}
+
+func (r *FollowerRetrivalRepository) GetCount(id uuid.UUID, viewerId uuid.UUID, ctx context.Context) (int, error) {
+
+	query := `SELECT COUNT(userGuid) FROM follower
+			  WHERE userGuid = ?
+			  AND NOT EXISTS (SELECT 1 FROM block WHERE blockerGuid = follower.followerGuid AND blockedGuid = ?)`
 	var count int
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, id, viewerId).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
 
 	return count, nil
 }
+
+func (r *FollowerRetrivalRepository) GetFollowingCount(id uuid.UUID, viewerId uuid.UUID, ctx context.Context) (int, error) {
+
+	query := `SELECT COUNT(followerGuid) FROM follower
+			  WHERE followerGuid = ?
+			  AND NOT EXISTS (SELECT 1 FROM block WHERE blockerGuid = follower.userGuid AND blockedGuid = ?)`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, id, viewerId).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *FollowerRetrivalRepository) IsFollowing(id uuid.UUID, followerId uuid.UUID, ctx context.Context) (bool, error) {
+	var following int
+	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM follower WHERE userGuid = ? AND followerGuid = ? LIMIT 1`,
+		id, followerId).Scan(&following)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("checking follow existence: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *FollowerRetrivalRepository) GetRelationship(viewerId uuid.UUID, targetId uuid.UUID, ctx context.Context) (*domain.Relationship, error) {
+	query := `SELECT
+			  EXISTS(SELECT 1 FROM follower WHERE userGuid = ? AND followerGuid = ?),
+			  EXISTS(SELECT 1 FROM follower WHERE userGuid = ? AND followerGuid = ?),
+			  EXISTS(SELECT 1 FROM follow_request WHERE userGuid = ? AND requesterGuid = ?),
+			  EXISTS(SELECT 1 FROM follow_request WHERE userGuid = ? AND requesterGuid = ?),
+			  EXISTS(SELECT 1 FROM block WHERE blockerGuid = ? AND blockedGuid = ?),
+			  EXISTS(SELECT 1 FROM block WHERE blockerGuid = ? AND blockedGuid = ?)`
+
+	relationship := domain.Relationship{
+		ViewerId: viewerId,
+		TargetId: targetId,
+	}
+
+	err := r.db.QueryRowContext(ctx, query,
+		targetId, viewerId,
+		viewerId, targetId,
+		targetId, viewerId,
+		viewerId, targetId,
+		viewerId, targetId,
+		targetId, viewerId).Scan(&relationship.Following, &relationship.FollowedBy,
+		&relationship.RequestPending, &relationship.RequestReceived,
+		&relationship.Blocking, &relationship.BlockedBy)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read relationship: %w", err)
+	}
+
+	relationship.Mutual = relationship.Following && relationship.FollowedBy
+	return &relationship, nil
+}
@@ -0,0 +1,108 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	followInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/follow"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FollowerWriterRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewFollowerWriterRepository(db *sql.DB, logger *zap.Logger) followInterface.FollowerWriterRepository {
+	return &FollowerWriterRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *FollowerWriterRepository) Follow(user domain.User, follower domain.User, ctx context.Context) error {
+	err := withTransaction(r.db, ctx, func(tx *sql.Tx) error {
+		return insertFollow(tx, user, follower, ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infof("%s now follows %s", follower.Id, user.Id)
+	return nil
+}
+
+func (r *FollowerWriterRepository) Unfollow(id uuid.UUID, followerId uuid.UUID, ctx context.Context) error {
+	err := withTransaction(r.db, ctx, func(tx *sql.Tx) error {
+		removed, err := deleteFollow(tx, id, followerId, ctx)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return domain.ErrNotFollowing
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infof("%s no longer follows %s", followerId, id)
+	return nil
+}
+
+// insertFollow adds the follower row and bumps both profiles counters, it must be called inside a transaction.
+func insertFollow(tx *sql.Tx, user domain.User, follower domain.User, ctx context.Context) error {
+	query := `INSERT IGNORE INTO follower(userGuid, username, followerGuid, followerUsername)
+			  VALUES(?, ?, ?, ?)`
+
+	result, err := tx.ExecContext(ctx, query, user.Id, user.Username, follower.Id, follower.Username)
+	if err != nil {
+		return fmt.Errorf("unable to insert follow: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrAlreadyFollowing
+	}
+
+	return updateFollowCounts(tx, user.Id, follower.Id, 1, ctx)
+}
+
+// deleteFollow removes the follower row and decrements both profiles counters when it existed,
+// it must be called inside a transaction.
+func deleteFollow(tx *sql.Tx, id uuid.UUID, followerId uuid.UUID, ctx context.Context) (bool, error) {
+	result, err := tx.ExecContext(ctx, `DELETE FROM follower WHERE userGuid = ? AND followerGuid = ?`, id, followerId)
+	if err != nil {
+		return false, fmt.Errorf("unable to delete follow: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("unable to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	return true, updateFollowCounts(tx, id, followerId, -1, ctx)
+}
+
+func updateFollowCounts(tx *sql.Tx, id uuid.UUID, followerId uuid.UUID, delta int, ctx context.Context) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE profile SET followerCount = followerCount + ? WHERE userId = ?`, delta, id); err != nil {
+		return fmt.Errorf("unable to update follower count: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE profile SET followingCount = followingCount + ? WHERE userId = ?`, delta, followerId); err != nil {
+		return fmt.Errorf("unable to update following count: %w", err)
+	}
+
+	return nil
+}
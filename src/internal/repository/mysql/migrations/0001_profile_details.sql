@@ -0,0 +1,8 @@
+-- Adds the profile details columns to a profile table created before they were part of schema.sql.
+-- Run once against an existing database, a new database gets them from schema.sql.
+ALTER TABLE profile
+    ADD COLUMN displayName VARCHAR(50)   NOT NULL DEFAULT '',
+    ADD COLUMN bio         VARCHAR(160)  NOT NULL DEFAULT '',
+    ADD COLUMN avatarUrl   VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN location    VARCHAR(100)  NOT NULL DEFAULT '',
+    ADD COLUMN website     VARCHAR(2048) NOT NULL DEFAULT '';
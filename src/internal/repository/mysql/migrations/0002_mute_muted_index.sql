@@ -0,0 +1,3 @@
+-- Indexes mute by the muted profile, used to find who muted a profile and to remove its mutes when it is deleted.
+-- Run once against an existing database, a new database gets it from schema.sql.
+ALTER TABLE mute ADD KEY ix_mute_muted (mutedGuid);
@@ -0,0 +1,146 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	muteInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/mute"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type MuteRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+func NewMuteRepository(db *sql.DB, logger *zap.Logger) muteInterface.MuteRepository {
+	return &MuteRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *MuteRepository) Mute(muterId uuid.UUID, mutedId uuid.UUID, ctx context.Context) error {
+	query := `INSERT IGNORE INTO mute(muterGuid, mutedGuid) VALUES(?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, muterId, mutedId)
+	if err != nil {
+		return fmt.Errorf("unable to insert mute: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrAlreadyMuted
+	}
+
+	r.logger.Sugar().Infof("%s muted %s", muterId, mutedId)
+	return nil
+}
+
+func (r *MuteRepository) Unmute(muterId uuid.UUID, mutedId uuid.UUID, ctx context.Context) error {
+	query := `DELETE FROM mute WHERE muterGuid = ? AND mutedGuid = ?`
+
+	result, err := r.db.ExecContext(ctx, query, muterId, mutedId)
+	if err != nil {
+		return fmt.Errorf("unable to delete mute: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to read affected rows: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotMuted
+	}
+
+	r.logger.Sugar().Infof("%s unmuted %s", muterId, mutedId)
+	return nil
+}
+
+func (r *MuteRepository) GetPage(muterId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.Mute], error) {
+	offset := (pageinationOptions.Page - 1) * pageinationOptions.Size
+
+	query := `SELECT muterGuid, mutedGuid, createdAt FROM mute WHERE muterGuid = ?
+			  ORDER BY createdAt, id
+			  LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, muterId, pageinationOptions.Size, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mutes []domain.Mute
+
+	for rows.Next() {
+		var mute domain.Mute
+
+		if err := rows.Scan(&mute.UserId, &mute.MutedId, &mute.CreatedAt); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		mutes = append(mutes, mute)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(id) FROM mute WHERE muterGuid = ?`, muterId).Scan(&total); err != nil {
+		return nil, fmt.Errorf("error getting count: %w", err)
+	}
+
+	return &domain.PagedResult[[]domain.Mute]{
+		Items: mutes,
+		Page:  pageinationOptions.Page,
+		Size:  pageinationOptions.Size,
+		Total: total,
+	}, nil
+}
+
+// AreMuted reports for each of ids whether muterId has muted it, every id is present in the result.
+func (r *MuteRepository) AreMuted(muterId uuid.UUID, ids []uuid.UUID, ctx context.Context) (map[uuid.UUID]bool, error) {
+	muted := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return muted, nil
+	}
+
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, muterId)
+	for _, id := range ids {
+		muted[id] = false
+		args = append(args, id)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	query := fmt.Sprintf(`SELECT mutedGuid FROM mute WHERE muterGuid = ? AND mutedGuid IN (%s)`, placeholders)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		muted[id] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	return muted, nil
+}
@@ -23,11 +23,19 @@ func NewProfileRetrievalRepository(db *sql.DB) profileInterfaces.ProfileRetrieva
 
 func (r *ProfileRetrievalRepository) GetById(id uuid.UUID, ctx context.Context) (*domain.Profile, error) {
 
-	rows := r.db.QueryRowContext(ctx, "SELECT * FROM profile WHERE userId = ? LIMIT 1", id)
+	query := `SELECT userId, followerCount, followingCount, private,
+			  displayName, bio, avatarUrl, location, website
+			  FROM profile WHERE userId = ? LIMIT 1`
+
+	rows := r.db.QueryRowContext(ctx, query, id)
 
 	var profile domain.Profile
 	if err := rows.Scan(&profile.UserId,
-		&profile.FollowerCount, &profile.FollowingCount, &profile.Private); err != nil {
+		&profile.FollowerCount, &profile.FollowingCount, &profile.Private,
+		&profile.DisplayName, &profile.Bio, &profile.AvatarURL, &profile.Location, &profile.Website); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+		}
 		return nil, fmt.Errorf("unable to scan row: %v", err)
 	}
 	if err := rows.Err(); err != nil {
@@ -39,15 +47,48 @@ func (r *ProfileRetrievalRepository) GetById(id uuid.UUID, ctx context.Context)
 
 func (r *ProfileRetrievalRepository) ProfileExits(id uuid.UUID, ctx context.Context) (bool, error) {
 	var count int
-	err := r.db.QueryRow("SELECT * FROM profile WHERE userId = ? LIMIT 1", id).Scan(&count)
+	err := r.db.QueryRowContext(ctx, "SELECT 1 FROM profile WHERE userId = ? LIMIT 1", id).Scan(&count)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// No rows found, which means profile doesn't exist
 			return false, nil
 		}
 
-		return false, fmt.Errorf("checking profile existence: %v", err)
+		return false, fmt.Errorf("checking profile existence: %w", err)
 	}
 
 	return true, nil
 }
+
+// GetTopByFollowerCount returns the limit most followed profiles, most followed first.
+func (r *ProfileRetrievalRepository) GetTopByFollowerCount(limit int, ctx context.Context) ([]domain.Profile, error) {
+	query := `SELECT userId, followerCount, followingCount, private,
+			  displayName, bio, avatarUrl, location, website
+			  FROM profile ORDER BY followerCount DESC LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []domain.Profile
+
+	for rows.Next() {
+		var profile domain.Profile
+
+		if err := rows.Scan(&profile.UserId,
+			&profile.FollowerCount, &profile.FollowingCount, &profile.Private,
+			&profile.DisplayName, &profile.Bio, &profile.AvatarURL, &profile.Location, &profile.Website); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	return profiles, nil
+}
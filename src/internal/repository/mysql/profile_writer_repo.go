@@ -3,9 +3,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
 	profileInterfaces "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -22,12 +24,14 @@ func NewWriterRetrievalRepository(db *sql.DB, logger *zap.Logger) profileInterfa
 }
 
 func (s *ProfileWriterRepository) Create(profile domain.Profile, ctx context.Context) error {
-	query := `INSERT INTO profile(userId, followerCount, followingCount, private)
-			  VALUES(?, ?, ?, ?)`
+	query := `INSERT INTO profile(userId, followerCount, followingCount, private,
+			  displayName, bio, avatarUrl, location, website)
+			  VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	s.logger.Sugar().Infof("writing to sql %s ", profile.UserId)
 
-	result, err := s.db.Exec(query, profile.UserId, profile.FollowerCount, profile.FollowingCount, profile.Private)
+	result, err := s.db.Exec(query, profile.UserId, profile.FollowerCount, profile.FollowingCount, profile.Private,
+		profile.DisplayName, profile.Bio, profile.AvatarURL, profile.Location, profile.Website)
 	if err != nil {
 		return err
 	}
@@ -40,3 +44,130 @@ func (s *ProfileWriterRepository) Create(profile domain.Profile, ctx context.Con
 	s.logger.Sugar().Infof("Profile %v created", lastId)
 	return nil
 }
+
+// Update locks the profile row and writes back what apply makes of it, so concurrent updates each merge into the
+// latest stored profile rather than overwriting one another.
+func (s *ProfileWriterRepository) Update(id uuid.UUID, apply func(domain.Profile) (domain.Profile, error),
+	ctx context.Context) error {
+	err := withTransaction(s.db, ctx, func(tx *sql.Tx) error {
+		query := `SELECT userId, followerCount, followingCount, private,
+				  displayName, bio, avatarUrl, location, website
+				  FROM profile WHERE userId = ? FOR UPDATE`
+
+		var current domain.Profile
+		if err := tx.QueryRowContext(ctx, query, id).Scan(&current.UserId,
+			&current.FollowerCount, &current.FollowingCount, &current.Private,
+			&current.DisplayName, &current.Bio, &current.AvatarURL, &current.Location, &current.Website); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+			}
+			return fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		profile, err := apply(current)
+		if err != nil {
+			return err
+		}
+
+		query = `UPDATE profile SET private = ?, displayName = ?, bio = ?,
+				 avatarUrl = ?, location = ?, website = ?
+				 WHERE userId = ?`
+
+		if _, err := tx.ExecContext(ctx, query, profile.Private, profile.DisplayName, profile.Bio,
+			profile.AvatarURL, profile.Location, profile.Website, id); err != nil {
+			return fmt.Errorf("unable to update profile: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logger.Sugar().Infof("Profile %s updated", id)
+	return nil
+}
+
+// Delete removes the profile along with every follow, follow request, block and mute it is part of, decrementing the
+// counters of the profiles on the other side. The ids of those profiles are returned so callers can purge them.
+func (s *ProfileWriterRepository) Delete(id uuid.UUID, ctx context.Context) ([]uuid.UUID, error) {
+	var affected []uuid.UUID
+
+	err := withTransaction(s.db, ctx, func(tx *sql.Tx) error {
+		followed, err := selectIds(tx, `SELECT userGuid FROM follower WHERE followerGuid = ? FOR UPDATE`, id, ctx)
+		if err != nil {
+			return err
+		}
+
+		followers, err := selectIds(tx, `SELECT followerGuid FROM follower WHERE userGuid = ? FOR UPDATE`, id, ctx)
+		if err != nil {
+			return err
+		}
+
+		statements := []struct {
+			query string
+			args  []any
+		}{
+			{`UPDATE profile SET followerCount = followerCount - 1
+			  WHERE userId IN (SELECT userGuid FROM follower WHERE followerGuid = ?)`, []any{id}},
+			{`UPDATE profile SET followingCount = followingCount - 1
+			  WHERE userId IN (SELECT followerGuid FROM follower WHERE userGuid = ?)`, []any{id}},
+			{`DELETE FROM follower WHERE userGuid = ? OR followerGuid = ?`, []any{id, id}},
+			{`DELETE FROM follow_request WHERE userGuid = ? OR requesterGuid = ?`, []any{id, id}},
+			{`DELETE FROM block WHERE blockerGuid = ? OR blockedGuid = ?`, []any{id, id}},
+			{`DELETE FROM mute WHERE muterGuid = ? OR mutedGuid = ?`, []any{id, id}},
+		}
+
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement.query, statement.args...); err != nil {
+				return fmt.Errorf("unable to remove relationships: %w", err)
+			}
+		}
+
+		result, err := tx.ExecContext(ctx, `DELETE FROM profile WHERE userId = ?`, id)
+		if err != nil {
+			return fmt.Errorf("unable to delete profile: %w", err)
+		}
+
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("unable to read affected rows: %w", err)
+		}
+		if deleted == 0 {
+			return fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+		}
+
+		affected = append(followed, followers...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Sugar().Infof("Profile %s deleted, %d related profiles updated", id, len(affected))
+	return affected, nil
+}
+
+func selectIds(tx *sql.Tx, query string, id uuid.UUID, ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("unable to scan row: %w", err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row error: %w", err)
+	}
+
+	return ids, nil
+}
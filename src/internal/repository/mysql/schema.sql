@@ -0,0 +1,61 @@
+-- schema.sql creates a new database. An existing database is brought up to date by running the scripts in
+-- migrations/ it hasn't had yet, in order.
+
+CREATE TABLE IF NOT EXISTS profile (
+    userId         CHAR(36)      NOT NULL,
+    followerCount  INT           NOT NULL DEFAULT 0,
+    followingCount INT           NOT NULL DEFAULT 0,
+    private        TINYINT(1)    NOT NULL DEFAULT 0,
+    displayName    VARCHAR(50)   NOT NULL DEFAULT '',
+    bio            VARCHAR(160)  NOT NULL DEFAULT '',
+    avatarUrl      VARCHAR(2048) NOT NULL DEFAULT '',
+    location       VARCHAR(100)  NOT NULL DEFAULT '',
+    website        VARCHAR(2048) NOT NULL DEFAULT '',
+    PRIMARY KEY (userId),
+    KEY ix_profile_follower_count (followerCount)
+);
+
+CREATE TABLE IF NOT EXISTS follower (
+    id               BIGINT       NOT NULL AUTO_INCREMENT,
+    userGuid         CHAR(36)     NOT NULL,
+    username         VARCHAR(255) NOT NULL,
+    followerGuid     CHAR(36)     NOT NULL,
+    followerUsername VARCHAR(255) NOT NULL,
+    createdAt        DATETIME(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_follower_user_follower (userGuid, followerGuid),
+    KEY ix_follower_user_created (userGuid, createdAt, id),
+    KEY ix_follower_follower_created (followerGuid, createdAt, id)
+);
+
+CREATE TABLE IF NOT EXISTS follow_request (
+    id                BIGINT       NOT NULL AUTO_INCREMENT,
+    userGuid          CHAR(36)     NOT NULL,
+    username          VARCHAR(255) NOT NULL,
+    requesterGuid     CHAR(36)     NOT NULL,
+    requesterUsername VARCHAR(255) NOT NULL,
+    createdAt         DATETIME(6)  NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_follow_request_user_requester (userGuid, requesterGuid),
+    KEY ix_follow_request_requester (requesterGuid)
+);
+
+CREATE TABLE IF NOT EXISTS block (
+    id          BIGINT      NOT NULL AUTO_INCREMENT,
+    blockerGuid CHAR(36)    NOT NULL,
+    blockedGuid CHAR(36)    NOT NULL,
+    createdAt   DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_block_blocker_blocked (blockerGuid, blockedGuid),
+    KEY ix_block_blocked (blockedGuid)
+);
+
+CREATE TABLE IF NOT EXISTS mute (
+    id        BIGINT      NOT NULL AUTO_INCREMENT,
+    muterGuid CHAR(36)    NOT NULL,
+    mutedGuid CHAR(36)    NOT NULL,
+    createdAt DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_mute_muter_muted (muterGuid, mutedGuid),
+    KEY ix_mute_muted (mutedGuid)
+);
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	blockInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/block"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BlockService struct {
+	blockRepo              blockInterface.BlockRepository
+	profileRetrivelService ProfileRetrievalService
+	logger                 *zap.Logger
+}
+
+func NewBlockService(blockRepo blockInterface.BlockRepository,
+	profileService ProfileRetrievalService,
+	logger zap.Logger) *BlockService {
+	return &BlockService{
+		blockRepo:              blockRepo,
+		profileRetrivelService: profileService,
+		logger:                 &logger,
+	}
+}
+
+// Block stops blockedId from seeing or following blockerId, removing any follows between them.
+func (s *BlockService) Block(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) error {
+	if blockerId == blockedId {
+		return domain.ErrCannotBlockSelf
+	}
+
+	exists, err := s.profileRetrivelService.ProfileExists(blockedId, ctx)
+	if err != nil {
+		return fmt.Errorf("error checking if profile exists: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("profile %s: %w", blockedId, domain.ErrProfileNotFound)
+	}
+
+	if err := s.blockRepo.Block(blockerId, blockedId, ctx); err != nil {
+		return fmt.Errorf("error blocking %s: %w", blockedId, err)
+	}
+
+	s.profileRetrivelService.Invalidate(blockerId, blockedId)
+	s.logger.Sugar().Infof("%s blocked %s", blockerId, blockedId)
+	return nil
+}
+
+func (s *BlockService) Unblock(blockerId uuid.UUID, blockedId uuid.UUID, ctx context.Context) error {
+	if err := s.blockRepo.Unblock(blockerId, blockedId, ctx); err != nil {
+		return fmt.Errorf("error unblocking %s: %w", blockedId, err)
+	}
+
+	s.profileRetrivelService.Invalidate(blockerId, blockedId)
+	s.logger.Sugar().Infof("%s unblocked %s", blockerId, blockedId)
+	return nil
+}
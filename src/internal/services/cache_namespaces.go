@@ -0,0 +1,48 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	"github.com/google/uuid"
+)
+
+// cacheNamespaces are the typed views over the shared cache, each namespace owns its key prefix.
+type cacheNamespaces struct {
+	cache         caching.Cache
+	profiles      *caching.TypedCache[uuid.UUID, domain.Profile]
+	exists        *caching.TypedCache[uuid.UUID, bool]
+	relationships *caching.TypedCache[relationshipKey, domain.Relationship]
+	// blocks sits under the relationship prefix, so purging relationships also purges block checks.
+	blocks *caching.TypedCache[relationshipKey, bool]
+}
+
+func newCacheNamespaces(cache caching.Cache) cacheNamespaces {
+	return cacheNamespaces{
+		cache:         cache,
+		profiles:      caching.NewTypedCache[uuid.UUID, domain.Profile](cache, "profile"),
+		exists:        caching.NewTypedCache[uuid.UUID, bool](cache, "exists"),
+		relationships: caching.NewTypedCache[relationshipKey, domain.Relationship](cache, "relationship"),
+		blocks:        caching.NewTypedCache[relationshipKey, bool](cache, "relationship-blocked"),
+	}
+}
+
+type relationshipKey struct {
+	viewerId uuid.UUID
+	targetId uuid.UUID
+}
+
+func (k relationshipKey) String() string {
+	return fmt.Sprintf("%s-%s", k.viewerId, k.targetId)
+}
+
+// invalidateUsers drops every entry tagged with any of ids, covering their profiles, existence checks and relationships.
+func (n cacheNamespaces) invalidateUsers(ids ...uuid.UUID) {
+	tags := make([]string, 0, len(ids))
+	for _, id := range ids {
+		tags = append(tags, caching.UserTag(id))
+	}
+
+	n.cache.Invalidate(tags...)
+}
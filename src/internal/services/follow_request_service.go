@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	followInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/follow"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FollowRequestService struct {
+	followRequestRepo followInterface.FollowRequestRepository
+	caches            cacheNamespaces
+	logger            *zap.Logger
+}
+
+func NewFollowRequestService(followRequestRepo followInterface.FollowRequestRepository,
+	cache caching.Cache,
+	logger zap.Logger) *FollowRequestService {
+	return &FollowRequestService{
+		followRequestRepo: followRequestRepo,
+		caches:            newCacheNamespaces(cache),
+		logger:            &logger,
+	}
+}
+
+func (s *FollowRequestService) GetIncoming(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.FollowRequest], error) {
+	result, err := s.followRequestRepo.GetIncoming(id, pageinationOptions, ctx)
+	if err != nil {
+		return domain.PagedResult[[]domain.FollowRequest]{}, fmt.Errorf("error getting incoming follow requests for %s, %w", id, err)
+	}
+
+	return *result, nil
+}
+
+func (s *FollowRequestService) GetOutgoing(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.FollowRequest], error) {
+	result, err := s.followRequestRepo.GetOutgoing(id, pageinationOptions, ctx)
+	if err != nil {
+		return domain.PagedResult[[]domain.FollowRequest]{}, fmt.Errorf("error getting outgoing follow requests for %s, %w", id, err)
+	}
+
+	return *result, nil
+}
+
+// Approve turns the pending request from requesterId into a follow of id.
+func (s *FollowRequestService) Approve(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error {
+	if err := s.followRequestRepo.Approve(id, requesterId, ctx); err != nil {
+		return fmt.Errorf("error approving follow request from %s: %w", requesterId, err)
+	}
+
+	s.caches.invalidateUsers(id, requesterId)
+	s.logger.Sugar().Infof("%s approved follow request from %s", id, requesterId)
+	return nil
+}
+
+// Reject removes the pending request from requesterId to id without following.
+func (s *FollowRequestService) Reject(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error {
+	if err := s.followRequestRepo.Delete(id, requesterId, ctx); err != nil {
+		return fmt.Errorf("error rejecting follow request from %s: %w", requesterId, err)
+	}
+
+	s.caches.invalidateUsers(id, requesterId)
+	s.logger.Sugar().Infof("%s rejected follow request from %s", id, requesterId)
+	return nil
+}
+
+// Cancel withdraws the pending request requesterId sent to id.
+func (s *FollowRequestService) Cancel(id uuid.UUID, requesterId uuid.UUID, ctx context.Context) error {
+	if err := s.followRequestRepo.Delete(id, requesterId, ctx); err != nil {
+		return fmt.Errorf("error cancelling follow request to %s: %w", id, err)
+	}
+
+	s.caches.invalidateUsers(id, requesterId)
+	s.logger.Sugar().Infof("%s cancelled follow request to %s", requesterId, id)
+	return nil
+}
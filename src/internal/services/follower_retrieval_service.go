@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
 	followInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/follow"
 	"github.com/google/uuid"
@@ -13,34 +14,185 @@ import (
 type FollowerRetrievalService struct {
 	followerRetrievalRepo  followInterface.FollowerRetrivalRepository
 	profileRetrivelService ProfileRetrievalService
+	caches                 cacheNamespaces
+	cursorSigner           *domain.CursorSigner
 	logger                 *zap.Logger
 }
 
 func NewFollowerRetrivalService(followerRepo followInterface.FollowerRetrivalRepository,
 	profileService ProfileRetrievalService,
+	cache caching.Cache,
+	cursorSigner *domain.CursorSigner,
 	logger zap.Logger) *FollowerRetrievalService {
 	return &FollowerRetrievalService{
 		followerRetrievalRepo:  followerRepo,
 		profileRetrivelService: profileService,
+		caches:                 newCacheNamespaces(cache),
+		cursorSigner:           cursorSigner,
 		logger:                 &logger,
 	}
 }
 
-func (s *FollowerRetrievalService) GetPage(id uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.User], error) {
+// GetPage returns the followers of id, private profiles are only visible to their owner and approved followers.
+func (s *FollowerRetrievalService) GetPage(id uuid.UUID, callerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.User], error) {
+	return s.getPage(s.followerRetrievalRepo.GetPage, id, callerId, pageinationOptions, ctx)
+}
+
+// GetFollowingPage returns the profiles id follows, with the same visibility rules as GetPage.
+func (s *FollowerRetrievalService) GetFollowingPage(id uuid.UUID, callerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.User], error) {
+	return s.getPage(s.followerRetrievalRepo.GetFollowingPage, id, callerId, pageinationOptions, ctx)
+}
+
+func (s *FollowerRetrievalService) getPage(
+	get func(id uuid.UUID, viewerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (*domain.PagedResult[[]domain.User], error),
+	id uuid.UUID, callerId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.User], error) {
+	if err := s.ensureCanView(id, callerId, ctx); err != nil {
+		return domain.PagedResult[[]domain.User]{}, err
+	}
+
+	result, err := get(id, callerId, pageinationOptions, ctx)
+	if err != nil {
+		return domain.PagedResult[[]domain.User]{}, fmt.Errorf("error getting page for %s, %w", id, err)
+	}
+
+	s.logger.Info("succesfully returned page")
+	return *result, nil
+}
+
+// GetCursorPage is the keyset paginated form of GetPage, positions are exchanged as signed cursors.
+func (s *FollowerRetrievalService) GetCursorPage(id uuid.UUID, callerId uuid.UUID, cursorOptions domain.CursorOptions, ctx context.Context) (domain.CursorPagedResult[[]domain.User], error) {
+	return s.getCursorPage(s.followerRetrievalRepo.GetCursorPage, domain.CursorScope("followers", id), id, callerId, cursorOptions, ctx)
+}
+
+// GetFollowingCursorPage is the keyset paginated form of GetFollowingPage.
+func (s *FollowerRetrievalService) GetFollowingCursorPage(id uuid.UUID, callerId uuid.UUID, cursorOptions domain.CursorOptions, ctx context.Context) (domain.CursorPagedResult[[]domain.User], error) {
+	return s.getCursorPage(s.followerRetrievalRepo.GetFollowingCursorPage, domain.CursorScope("following", id), id, callerId, cursorOptions, ctx)
+}
+
+func (s *FollowerRetrievalService) getCursorPage(
+	get func(id uuid.UUID, viewerId uuid.UUID, cursor *domain.Cursor, limit int, ctx context.Context) (*domain.KeysetPage[[]domain.User], error),
+	scope string, id uuid.UUID, callerId uuid.UUID, cursorOptions domain.CursorOptions, ctx context.Context) (domain.CursorPagedResult[[]domain.User], error) {
+	var cursor *domain.Cursor
+	if cursorOptions.Cursor != "" {
+		decoded, err := s.cursorSigner.Decode(cursorOptions.Cursor, scope)
+		if err != nil {
+			return domain.CursorPagedResult[[]domain.User]{}, err
+		}
+		cursor = &decoded
+	}
+
+	if err := s.ensureCanView(id, callerId, ctx); err != nil {
+		return domain.CursorPagedResult[[]domain.User]{}, err
+	}
+
+	page, err := get(id, callerId, cursor, cursorOptions.Limit, ctx)
+	if err != nil {
+		return domain.CursorPagedResult[[]domain.User]{}, fmt.Errorf("error getting page for %s, %w", id, err)
+	}
+
+	result := domain.CursorPagedResult[[]domain.User]{
+		Items: page.Items,
+		Limit: cursorOptions.Limit,
+	}
+	if len(page.Items) == 0 {
+		return result, nil
+	}
+
+	// reading backwards means we came from a later page, reading forwards from a cursor means we came from an earlier one
+	backward := cursor != nil && cursor.Backward
+	hasNext := backward || page.HasMore
+	hasPrev := cursor != nil && (!backward || page.HasMore)
+
+	if hasNext {
+		next := page.Last
+		next.Backward = false
+		if result.NextCursor, err = s.cursorSigner.Encode(next, scope); err != nil {
+			return domain.CursorPagedResult[[]domain.User]{}, err
+		}
+	}
+
+	if hasPrev {
+		prev := page.First
+		prev.Backward = true
+		if result.PrevCursor, err = s.cursorSigner.Encode(prev, scope); err != nil {
+			return domain.CursorPagedResult[[]domain.User]{}, err
+		}
+	}
+
+	s.logger.Info("succesfully returned page")
+	return result, nil
+}
+
+// ensureCanView checks id exists and is visible to callerId, private profiles are only visible to their owner
+// and approved followers and a profile that blocked the caller is reported as not found.
+func (s *FollowerRetrievalService) ensureCanView(id uuid.UUID, callerId uuid.UUID, ctx context.Context) error {
 	exists, err := s.profileRetrivelService.profileRetrievalRepo.ProfileExits(id, ctx)
 	if err != nil {
-		return domain.PagedResult[[]domain.User]{}, fmt.Errorf("error checking if profile exists: %w", err)
+		return fmt.Errorf("error checking if profile exists: %w", err)
 	}
 
 	if !exists {
-		return domain.PagedResult[[]domain.User]{}, fmt.Errorf("profile %s does not exist!", id)
+		return fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+	}
+
+	if id == callerId {
+		return nil
 	}
 
-	result, err := s.followerRetrievalRepo.GetPage(id, pageinationOptions, ctx)
+	hidden, err := s.profileRetrivelService.IsHiddenFrom(id, callerId, ctx)
 	if err != nil {
-		return domain.PagedResult[[]domain.User]{}, fmt.Errorf("error getting page for %s, %w", id, err)
+		return err
 	}
 
-	s.logger.Info("succesfully returned page")
-	return *result, nil
+	if hidden {
+		return fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+	}
+
+	profile, err := s.profileRetrivelService.GetById(id, ctx)
+	if err != nil {
+		return fmt.Errorf("error getting profile %s: %w", id, err)
+	}
+
+	if !profile.Private {
+		return nil
+	}
+
+	following, err := s.followerRetrievalRepo.IsFollowing(id, callerId, ctx)
+	if err != nil {
+		return fmt.Errorf("error checking if %s follows %s: %w", callerId, id, err)
+	}
+
+	if !following {
+		s.logger.Sugar().Infof("%s denied access to private profile %s", callerId, id)
+		return fmt.Errorf("profile %s is private: %w", id, domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// GetRelationship reports how viewerId and targetId follow each other in a single lookup.
+func (s *FollowerRetrievalService) GetRelationship(viewerId uuid.UUID, targetId uuid.UUID, ctx context.Context) (domain.Relationship, error) {
+	exists, err := s.profileRetrivelService.ProfileExists(targetId, ctx)
+	if err != nil {
+		return domain.Relationship{}, fmt.Errorf("error checking if profile exists: %w", err)
+	}
+
+	if !exists {
+		return domain.Relationship{}, fmt.Errorf("profile %s: %w", targetId, domain.ErrProfileNotFound)
+	}
+
+	key := relationshipKey{viewerId: viewerId, targetId: targetId}
+	relationship, err := s.caches.relationships.GetOrCreate(key, s.profileRetrivelService.ttls().Relationship, func(ctx context.Context) (domain.Relationship, error) {
+		relationship, err := s.followerRetrievalRepo.GetRelationship(viewerId, targetId, ctx)
+		if err != nil {
+			return domain.Relationship{}, err
+		}
+
+		return *relationship, nil
+	}, []string{caching.UserTag(viewerId), caching.UserTag(targetId)}, ctx)
+	if err != nil {
+		return domain.Relationship{}, fmt.Errorf("error getting relationship with %s: %w", targetId, err)
+	}
+
+	return relationship, nil
 }
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	client "github.com/RobsonDevCode/go-profile-service/src/internal/clients/user"
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	followInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/follow"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type FollowerWriterService struct {
+	followerWriterRepo     followInterface.FollowerWriterRepository
+	followRequestRepo      followInterface.FollowRequestRepository
+	profileRetrivelService ProfileRetrievalService
+	userClient             *client.UserClient
+	logger                 *zap.Logger
+}
+
+func NewFollowerWriterService(followerRepo followInterface.FollowerWriterRepository,
+	followRequestRepo followInterface.FollowRequestRepository,
+	profileService ProfileRetrievalService,
+	userClient *client.UserClient,
+	logger zap.Logger) *FollowerWriterService {
+	return &FollowerWriterService{
+		followerWriterRepo:     followerRepo,
+		followRequestRepo:      followRequestRepo,
+		profileRetrivelService: profileService,
+		userClient:             userClient,
+		logger:                 &logger,
+	}
+}
+
+// Follow makes followerId follow id, when id is a private profile a follow request is stored instead
+// and pending is returned as true.
+func (s *FollowerWriterService) Follow(id uuid.UUID, followerId uuid.UUID, ctx context.Context) (pending bool, err error) {
+	if id == followerId {
+		return false, domain.ErrCannotFollowSelf
+	}
+
+	if err := s.ensureProfilesExist(ctx, id, followerId); err != nil {
+		return false, err
+	}
+
+	blocked, err := s.profileRetrivelService.blockRepo.EitherBlocked(id, followerId, ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking blocks between %s and %s: %w", followerId, id, err)
+	}
+
+	if blocked {
+		return false, fmt.Errorf("%s can't follow %s: %w", followerId, id, domain.ErrBlocked)
+	}
+
+	profile, err := s.profileRetrivelService.GetById(id, ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting profile %s: %w", id, err)
+	}
+
+	user, err := s.getUser(id, ctx)
+	if err != nil {
+		return false, err
+	}
+
+	follower, err := s.getUser(followerId, ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if profile.Private {
+		if err := s.followRequestRepo.Create(user, follower, ctx); err != nil {
+			return false, fmt.Errorf("error requesting to follow %s: %w", id, err)
+		}
+
+		s.profileRetrivelService.Invalidate(id, followerId)
+		s.logger.Sugar().Infof("%s requested to follow %s", followerId, id)
+		return true, nil
+	}
+
+	if err := s.followerWriterRepo.Follow(user, follower, ctx); err != nil {
+		return false, fmt.Errorf("error following %s: %w", id, err)
+	}
+
+	s.profileRetrivelService.Invalidate(id, followerId)
+	s.logger.Sugar().Infof("%s followed %s", followerId, id)
+	return false, nil
+}
+
+func (s *FollowerWriterService) Unfollow(id uuid.UUID, followerId uuid.UUID, ctx context.Context) error {
+	if id == followerId {
+		return domain.ErrCannotFollowSelf
+	}
+
+	if err := s.followerWriterRepo.Unfollow(id, followerId, ctx); err != nil {
+		return fmt.Errorf("error unfollowing %s: %w", id, err)
+	}
+
+	s.profileRetrivelService.Invalidate(id, followerId)
+	s.logger.Sugar().Infof("%s unfollowed %s", followerId, id)
+	return nil
+}
+
+func (s *FollowerWriterService) ensureProfilesExist(ctx context.Context, ids ...uuid.UUID) error {
+	for _, id := range ids {
+		exists, err := s.profileRetrivelService.ProfileExists(id, ctx)
+		if err != nil {
+			return fmt.Errorf("error checking if profile exists: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+		}
+	}
+
+	return nil
+}
+
+func (s *FollowerWriterService) getUser(id uuid.UUID, ctx context.Context) (domain.User, error) {
+	user, err := s.userClient.Get(id, ctx)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("error getting user %s: %w", id, err)
+	}
+
+	return domain.User{
+		Id:       id,
+		Username: user.Username,
+	}, nil
+}
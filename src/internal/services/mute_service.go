@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
+	muteInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/mute"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type MuteService struct {
+	muteRepo               muteInterface.MuteRepository
+	profileRetrivelService ProfileRetrievalService
+	logger                 *zap.Logger
+}
+
+func NewMuteService(muteRepo muteInterface.MuteRepository,
+	profileService ProfileRetrievalService,
+	logger zap.Logger) *MuteService {
+	return &MuteService{
+		muteRepo:               muteRepo,
+		profileRetrivelService: profileService,
+		logger:                 &logger,
+	}
+}
+
+// Mute flags mutedId for muterId, follows and counts are left untouched.
+func (s *MuteService) Mute(muterId uuid.UUID, mutedId uuid.UUID, ctx context.Context) error {
+	if muterId == mutedId {
+		return domain.ErrCannotMuteSelf
+	}
+
+	exists, err := s.profileRetrivelService.ProfileExists(mutedId, ctx)
+	if err != nil {
+		return fmt.Errorf("error checking if profile exists: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("profile %s: %w", mutedId, domain.ErrProfileNotFound)
+	}
+
+	if err := s.muteRepo.Mute(muterId, mutedId, ctx); err != nil {
+		return fmt.Errorf("error muting %s: %w", mutedId, err)
+	}
+
+	s.logger.Sugar().Infof("%s muted %s", muterId, mutedId)
+	return nil
+}
+
+func (s *MuteService) Unmute(muterId uuid.UUID, mutedId uuid.UUID, ctx context.Context) error {
+	if err := s.muteRepo.Unmute(muterId, mutedId, ctx); err != nil {
+		return fmt.Errorf("error unmuting %s: %w", mutedId, err)
+	}
+
+	s.logger.Sugar().Infof("%s unmuted %s", muterId, mutedId)
+	return nil
+}
+
+func (s *MuteService) GetPage(muterId uuid.UUID, pageinationOptions domain.PageinationOptions, ctx context.Context) (domain.PagedResult[[]domain.Mute], error) {
+	result, err := s.muteRepo.GetPage(muterId, pageinationOptions, ctx)
+	if err != nil {
+		return domain.PagedResult[[]domain.Mute]{}, fmt.Errorf("error getting muted profiles for %s, %w", muterId, err)
+	}
+
+	return *result, nil
+}
+
+// AreMuted lets downstream services check a batch of profiles against muterId in one call.
+func (s *MuteService) AreMuted(muterId uuid.UUID, ids []uuid.UUID, ctx context.Context) (map[uuid.UUID]bool, error) {
+	muted, err := s.muteRepo.AreMuted(muterId, ids, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error checking muted profiles for %s, %w", muterId, err)
+	}
+
+	return muted, nil
+}
@@ -3,30 +3,41 @@ package services
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/RobsonDevCode/go-profile-service/src/internal/caching"
+	"github.com/RobsonDevCode/go-profile-service/src/internal/config"
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
 	profileInterfaces "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces"
+	blockInterface "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces/block"
 	"github.com/google/uuid"
 )
 
 type ProfileRetrievalService struct {
 	profileRetrievalRepo profileInterfaces.ProfileRetrievalRepository
-	cache                *caching.Cache
+	blockRepo            blockInterface.BlockRepository
+	caches               cacheNamespaces
+	settings             *config.Store
 }
 
-func NewProfileRetrievalService(repo profileInterfaces.ProfileRetrievalRepository, cache *caching.Cache) *ProfileRetrievalService {
+func NewProfileRetrievalService(repo profileInterfaces.ProfileRetrievalRepository,
+	blockRepo blockInterface.BlockRepository,
+	cache caching.Cache,
+	settings *config.Store) *ProfileRetrievalService {
 	return &ProfileRetrievalService{
 		profileRetrievalRepo: repo,
-		cache:                cache,
+		blockRepo:            blockRepo,
+		caches:               newCacheNamespaces(cache),
+		settings:             settings,
 	}
 }
 
-func (s *ProfileRetrievalService) GetById(id uuid.UUID, ctx context.Context) (domain.Profile, error) {
-	key := fmt.Sprintf("profile-%s", id)
+// ttls are read on each use so a config reload applies to the next entry cached.
+func (s *ProfileRetrievalService) ttls() config.CacheTTLs {
+	return s.settings.Get().Cache.TTL
+}
 
-	result, err := s.cache.GetOrCreate(key, time.Minute*3, func() (interface{}, error) {
+func (s *ProfileRetrievalService) GetById(id uuid.UUID, ctx context.Context) (domain.Profile, error) {
+	return s.caches.profiles.GetOrCreate(id, s.ttls().Profile, func(ctx context.Context) (domain.Profile, error) {
 		if id == uuid.Nil {
 			return domain.Profile{}, fmt.Errorf("argument error, id can't be null")
 		}
@@ -37,23 +48,43 @@ func (s *ProfileRetrievalService) GetById(id uuid.UUID, ctx context.Context) (do
 		}
 
 		return *profile, nil
-	})
+	}, []string{caching.UserTag(id)}, ctx)
+}
 
+// GetVisibleById returns the profile as seen by viewerId, a profile that has blocked the viewer is reported as not found.
+func (s *ProfileRetrievalService) GetVisibleById(id uuid.UUID, viewerId uuid.UUID, ctx context.Context) (domain.Profile, error) {
+	hidden, err := s.IsHiddenFrom(id, viewerId, ctx)
 	if err != nil {
 		return domain.Profile{}, err
 	}
 
-	profile, ok := result.(domain.Profile)
-	if !ok {
-		return domain.Profile{}, fmt.Errorf("unexcpected response type")
+	if hidden {
+		return domain.Profile{}, fmt.Errorf("profile %s: %w", id, domain.ErrProfileNotFound)
+	}
+
+	return s.GetById(id, ctx)
+}
+
+// IsHiddenFrom reports whether id has blocked viewerId and so should be invisible to them. The answer is tagged with
+// both users so blocking or unblocking either way evicts it.
+func (s *ProfileRetrievalService) IsHiddenFrom(id uuid.UUID, viewerId uuid.UUID, ctx context.Context) (bool, error) {
+	if id == viewerId {
+		return false, nil
+	}
+
+	key := relationshipKey{viewerId: viewerId, targetId: id}
+	blocked, err := s.caches.blocks.GetOrCreate(key, s.ttls().Relationship, func(ctx context.Context) (bool, error) {
+		return s.blockRepo.IsBlocked(id, viewerId, ctx)
+	}, []string{caching.UserTag(viewerId), caching.UserTag(id)}, ctx)
+	if err != nil {
+		return false, fmt.Errorf("error checking if %s blocked %s: %w", id, viewerId, err)
 	}
 
-	return profile, nil
+	return blocked, nil
 }
 
 func (s *ProfileRetrievalService) ProfileExists(id uuid.UUID, ctx context.Context) (bool, error) {
-	key := fmt.Sprintf("exists-%s", id)
-	result, err := s.cache.GetOrCreate(key, time.Minute*5, func() (interface{}, error) {
+	exists, err := s.caches.exists.GetOrCreate(id, s.ttls().Exists, func(ctx context.Context) (bool, error) {
 
 		if id == uuid.Nil {
 			return false, fmt.Errorf("argument error, user id can't be null")
@@ -65,15 +96,32 @@ func (s *ProfileRetrievalService) ProfileExists(id uuid.UUID, ctx context.Contex
 		}
 
 		return exists, nil
-	})
+	}, []string{caching.UserTag(id)}, ctx)
 	if err != nil {
-		return false, nil
+		return false, err
 	}
 
-	exists, ok := result.(bool)
-	if !ok {
-		return false, fmt.Errorf("unexpected response type")
+	return exists, nil
+}
+
+// Invalidate drops everything cached about each of ids.
+func (s *ProfileRetrievalService) Invalidate(ids ...uuid.UUID) {
+	s.caches.invalidateUsers(ids...)
+}
+
+// Warm loads the count most followed profiles into the cache so a fresh replica doesn't send every read to mysql.
+func (s *ProfileRetrievalService) Warm(count int, ctx context.Context) (int, error) {
+	profiles, err := s.profileRetrievalRepo.GetTopByFollowerCount(count, ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error reading most followed profiles: %w", err)
 	}
 
-	return exists, nil
+	ttls := s.ttls()
+	for _, profile := range profiles {
+		tag := caching.UserTag(profile.UserId)
+		s.caches.profiles.Set(profile.UserId, profile, ttls.Profile, tag)
+		s.caches.exists.Set(profile.UserId, true, ttls.Exists, tag)
+	}
+
+	return len(profiles), nil
 }
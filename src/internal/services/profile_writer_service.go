@@ -8,6 +8,7 @@ import (
 	client "github.com/RobsonDevCode/go-profile-service/src/internal/clients/user"
 	domain "github.com/RobsonDevCode/go-profile-service/src/internal/domain/models"
 	profileInterfaces "github.com/RobsonDevCode/go-profile-service/src/internal/repository/interfaces"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -95,5 +96,31 @@ func (s *ProfileWriterService) Create(profile domain.Profile, ctx context.Contex
 		return err
 	}
 
+	s.reader.Invalidate(profile.UserId)
+	return nil
+}
+
+// Update applies apply to the stored profile under a row lock, then reads the profile back so the result carries
+// its current counters rather than cached ones.
+func (s *ProfileWriterService) Update(id uuid.UUID, apply func(domain.Profile) (domain.Profile, error),
+	ctx context.Context) (domain.Profile, error) {
+	if err := s.profileWriterRepo.Update(id, apply, ctx); err != nil {
+		return domain.Profile{}, err
+	}
+
+	s.reader.Invalidate(id)
+	return s.reader.GetById(id, ctx)
+}
+
+// Delete removes the profile and every follow relationship it is part of, purging the cache for all affected profiles.
+func (s *ProfileWriterService) Delete(id uuid.UUID, ctx context.Context) error {
+	affected, err := s.profileWriterRepo.Delete(id, ctx)
+	if err != nil {
+		return err
+	}
+
+	s.reader.Invalidate(append(affected, id)...)
+
+	s.logger.Sugar().Infof("profile %s deleted", id)
 	return nil
 }